@@ -0,0 +1,22 @@
+// Package adapter bridges the core iterators with the persistent data structures in package immutable.
+// It lives in its own package so that neither rust nor immutable needs to import the other.
+package adapter
+
+import (
+	rust "github.com/dongrv/rust-go"
+	"github.com/dongrv/rust-go/immutable"
+)
+
+// CollectList drains an iterator into a persistent list, preserving element order.
+func CollectList[T any](iter rust.Iterator[T]) *immutable.List[T] {
+	return immutable.ListOf(rust.Collect(iter)...)
+}
+
+// CollectVector drains an iterator into a persistent vector, preserving element order.
+func CollectVector[T any](iter rust.Iterator[T]) *immutable.Vector[T] {
+	v := immutable.EmptyVector[T]()
+	rust.ForEach(iter, func(value T) {
+		v = v.Append(value)
+	})
+	return v
+}
@@ -0,0 +1,46 @@
+// Package adapter_test provides tests for the iterator adapters.
+package adapter_test
+
+import (
+	"testing"
+
+	rust "github.com/dongrv/rust-go"
+	"github.com/dongrv/rust-go/adapter"
+)
+
+func TestCollectList(t *testing.T) {
+	list := adapter.CollectList(rust.Map(rust.Range(1, 6, 1), func(x int) int { return x * 10 }))
+	if list.Size() != 5 {
+		t.Errorf("Expected size 5, got %d", list.Size())
+	}
+
+	expected := []int{10, 20, 30, 40, 50}
+	result := list.ToSlice()
+	for i, v := range expected {
+		if result[i] != v {
+			t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+		}
+	}
+
+	empty := adapter.CollectList(rust.Empty[int]())
+	if !empty.IsEmpty() {
+		t.Error("Expected CollectList of empty iterator to be empty")
+	}
+}
+
+func TestCollectVector(t *testing.T) {
+	vec := adapter.CollectVector(rust.Range(0, 100, 1))
+	if vec.Length() != 100 {
+		t.Errorf("Expected length 100, got %d", vec.Length())
+	}
+	for i := 0; i < vec.Length(); i++ {
+		if vec.Get(i) != i {
+			t.Errorf("Expected %d at index %d, got %d", i, i, vec.Get(i))
+		}
+	}
+
+	empty := adapter.CollectVector(rust.Empty[string]())
+	if !empty.IsEmpty() {
+		t.Error("Expected CollectVector of empty iterator to be empty")
+	}
+}
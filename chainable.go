@@ -1,6 +1,14 @@
 // package rust provides Rust-like programming constructs for Go
 package rust
 
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+)
+
 // Chainable provides Rust-like chainable operations for slices
 type Chainable[T any] struct {
 	data []T
@@ -83,6 +91,19 @@ func (c *Chainable[T]) ForEach(f func(T)) {
 	}
 }
 
+// ForEachIndexed calls a function for each element along with its index
+func (c *Chainable[T]) ForEachIndexed(f func(int, T)) {
+	for i, v := range c.data {
+		f(i, v)
+	}
+}
+
+// Tap calls f with the underlying slice and returns the chainable unchanged, for inspecting a chain midway
+func (c *Chainable[T]) Tap(f func([]T)) *Chainable[T] {
+	f(c.data)
+	return c
+}
+
 // All returns true if all elements satisfy the predicate
 func (c *Chainable[T]) All(predicate func(T) bool) bool {
 	for _, v := range c.data {
@@ -113,6 +134,47 @@ func (c *Chainable[T]) Find(predicate func(T) bool) Option[T] {
 	return None[T]()
 }
 
+// Count returns how many elements satisfy the predicate
+func (c *Chainable[T]) Count(predicate func(T) bool) int {
+	count := 0
+	for _, v := range c.data {
+		if predicate(v) {
+			count++
+		}
+	}
+	return count
+}
+
+// MinBy returns the minimum element with respect to the comparison function.
+// If several elements are equally minimum, the first one is returned.
+func (c *Chainable[T]) MinBy(compare func(T, T) int) Option[T] {
+	return MinBy(c.Iter(), compare)
+}
+
+// MaxBy returns the maximum element with respect to the comparison function.
+// If several elements are equally maximum, the last one is returned.
+func (c *Chainable[T]) MaxBy(compare func(T, T) int) Option[T] {
+	return MaxBy(c.Iter(), compare)
+}
+
+// First returns the first element, or None if empty
+func (c *Chainable[T]) First() Option[T] {
+	return c.Nth(0)
+}
+
+// Last returns the last element, or None if empty
+func (c *Chainable[T]) Last() Option[T] {
+	return c.Nth(len(c.data) - 1)
+}
+
+// Nth returns the element at index n, or None if n is out of range
+func (c *Chainable[T]) Nth(n int) Option[T] {
+	if n < 0 || n >= len(c.data) {
+		return None[T]()
+	}
+	return Some(c.data[n])
+}
+
 // Take takes the first n elements
 func (c *Chainable[T]) Take(n int) *Chainable[T] {
 	if n <= 0 {
@@ -135,6 +197,26 @@ func (c *Chainable[T]) Skip(n int) *Chainable[T] {
 	return NewChainable(c.data[n:])
 }
 
+// TakeWhile takes elements while the predicate holds, stopping at the first failure
+func (c *Chainable[T]) TakeWhile(predicate func(T) bool) *Chainable[T] {
+	for i, v := range c.data {
+		if !predicate(v) {
+			return NewChainable(c.data[:i])
+		}
+	}
+	return NewChainable(c.data)
+}
+
+// SkipWhile skips elements while the predicate holds and keeps the rest
+func (c *Chainable[T]) SkipWhile(predicate func(T) bool) *Chainable[T] {
+	for i, v := range c.data {
+		if !predicate(v) {
+			return NewChainable(c.data[i:])
+		}
+	}
+	return NewChainable([]T{})
+}
+
 // Reverse reverses the order of elements
 func (c *Chainable[T]) Reverse() *Chainable[T] {
 	result := make([]T, len(c.data))
@@ -144,6 +226,54 @@ func (c *Chainable[T]) Reverse() *Chainable[T] {
 	return NewChainable(result)
 }
 
+// SortBy returns a new Chainable sorted by less, leaving the original untouched
+func (c *Chainable[T]) SortBy(less func(T, T) bool) *Chainable[T] {
+	result := make([]T, len(c.data))
+	copy(result, c.data)
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i], result[j])
+	})
+	return NewChainable(result)
+}
+
+// SortStableBy returns a new Chainable sorted by less, keeping equal elements in their original order
+func (c *Chainable[T]) SortStableBy(less func(T, T) bool) *Chainable[T] {
+	result := make([]T, len(c.data))
+	copy(result, c.data)
+	sort.SliceStable(result, func(i, j int) bool {
+		return less(result[i], result[j])
+	})
+	return NewChainable(result)
+}
+
+// Shuffle returns a new Chainable with the elements randomly permuted by rng
+func (c *Chainable[T]) Shuffle(rng *rand.Rand) *Chainable[T] {
+	result := make([]T, len(c.data))
+	copy(result, c.data)
+	rng.Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+	return NewChainable(result)
+}
+
+// Sample returns n elements chosen at random by rng without replacement.
+// If n exceeds the number of elements, all elements are returned in random order.
+func (c *Chainable[T]) Sample(n int, rng *rand.Rand) *Chainable[T] {
+	if n <= 0 {
+		return NewChainable([]T{})
+	}
+	if n > len(c.data) {
+		n = len(c.data)
+	}
+	pool := make([]T, len(c.data))
+	copy(pool, c.data)
+	for i := 0; i < n; i++ {
+		j := i + rng.Intn(len(pool)-i)
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+	return NewChainable(pool[:n])
+}
+
 // Unique returns a new Chainable with duplicate elements removed
 func (c *Chainable[T]) Unique() *Chainable[T] {
 	seen := make(map[any]bool)
@@ -252,6 +382,31 @@ func (c *Chainable[T]) Prepend(elements ...T) *Chainable[T] {
 	return NewChainable(result)
 }
 
+// InsertAt inserts elements before the given index.
+// Panics if index is outside [0, len].
+func (c *Chainable[T]) InsertAt(index int, elements ...T) *Chainable[T] {
+	if index < 0 || index > len(c.data) {
+		panic(fmt.Sprintf("Chainable.InsertAt: index %d out of bounds [0, %d]", index, len(c.data)))
+	}
+	result := make([]T, len(c.data)+len(elements))
+	copy(result, c.data[:index])
+	copy(result[index:], elements)
+	copy(result[index+len(elements):], c.data[index:])
+	return NewChainable(result)
+}
+
+// RemoveAt removes the element at the given index.
+// Panics if index is outside [0, len).
+func (c *Chainable[T]) RemoveAt(index int) *Chainable[T] {
+	if index < 0 || index >= len(c.data) {
+		panic(fmt.Sprintf("Chainable.RemoveAt: index %d out of bounds [0, %d)", index, len(c.data)))
+	}
+	result := make([]T, len(c.data)-1)
+	copy(result, c.data[:index])
+	copy(result[index:], c.data[index+1:])
+	return NewChainable(result)
+}
+
 // Concat concatenates multiple chainables
 func (c *Chainable[T]) Concat(others ...*Chainable[T]) *Chainable[T] {
 	totalLen := len(c.data)
@@ -302,6 +457,145 @@ func Generate[T any](n int, generator func(int) T) *Chainable[T] {
 	return NewChainable(result)
 }
 
+// SortChainable returns a new Chainable with elements in ascending order
+func SortChainable[T cmp.Ordered](c *Chainable[T]) *Chainable[T] {
+	result := make([]T, len(c.data))
+	copy(result, c.data)
+	slices.Sort(result)
+	return NewChainable(result)
+}
+
+// GroupByChainable buckets elements by a computed key, preserving encounter order within each bucket
+func GroupByChainable[T any, K comparable](c *Chainable[T], key func(T) K) map[K][]T {
+	result := make(map[K][]T)
+	for _, v := range c.data {
+		k := key(v)
+		result[k] = append(result[k], v)
+	}
+	return result
+}
+
+// CountByChainable counts elements by a computed key
+func CountByChainable[T any, K comparable](c *Chainable[T], key func(T) K) map[K]int {
+	result := make(map[K]int)
+	for _, v := range c.data {
+		result[key(v)]++
+	}
+	return result
+}
+
+// MinChainable returns the minimum element
+func MinChainable[T cmp.Ordered](c *Chainable[T]) Option[T] {
+	return Min(c.Iter())
+}
+
+// MaxChainable returns the maximum element
+func MaxChainable[T cmp.Ordered](c *Chainable[T]) Option[T] {
+	return Max(c.Iter())
+}
+
+// SumChainable returns the sum of all elements, or zero for an empty chainable
+func SumChainable[T Number](c *Chainable[T]) T {
+	return Sum(c.Iter())
+}
+
+// AverageChainable returns the arithmetic mean of the elements, or None for an empty chainable
+func AverageChainable[T Number](c *Chainable[T]) Option[float64] {
+	return Average(c.Iter())
+}
+
+// ContainsChainable returns true if the chainable contains value
+func ContainsChainable[T comparable](c *Chainable[T], value T) bool {
+	return IndexOfChainable(c, value).IsSome()
+}
+
+// IndexOfChainable returns the position of the first element equal to value
+func IndexOfChainable[T comparable](c *Chainable[T], value T) Option[int] {
+	for i, v := range c.data {
+		if v == value {
+			return Some(i)
+		}
+	}
+	return None[int]()
+}
+
+// MapChainable applies a type-changing function to each element
+func MapChainable[T any, U any](c *Chainable[T], f func(T) U) *Chainable[U] {
+	result := make([]U, len(c.data))
+	for i, v := range c.data {
+		result[i] = f(v)
+	}
+	return NewChainable(result)
+}
+
+// FilterMapChainable applies f to each element and keeps only the unwrapped Some results
+func FilterMapChainable[T any, U any](c *Chainable[T], f func(T) Option[U]) *Chainable[U] {
+	var result []U
+	for _, v := range c.data {
+		if mapped := f(v); mapped.IsSome() {
+			result = append(result, mapped.Unwrap())
+		}
+	}
+	return NewChainable(result)
+}
+
+// ToMapChainable collects elements into a map using f to produce each key-value pair.
+// When several elements produce the same key, the last one wins.
+func ToMapChainable[T any, K comparable, V any](c *Chainable[T], f func(T) (K, V)) map[K]V {
+	result := make(map[K]V, len(c.data))
+	for _, v := range c.data {
+		k, val := f(v)
+		result[k] = val
+	}
+	return result
+}
+
+// ToSetChainable collects the distinct elements into a set
+func ToSetChainable[T comparable](c *Chainable[T]) map[T]struct{} {
+	result := make(map[T]struct{}, len(c.data))
+	for _, v := range c.data {
+		result[v] = struct{}{}
+	}
+	return result
+}
+
+// UniqueBy removes elements whose computed key has already been seen, keeping the first occurrence
+func UniqueBy[T any, K comparable](c *Chainable[T], key func(T) K) *Chainable[T] {
+	seen := make(map[K]bool)
+	var result []T
+	for _, v := range c.data {
+		k := key(v)
+		if !seen[k] {
+			seen[k] = true
+			result = append(result, v)
+		}
+	}
+	return NewChainable(result)
+}
+
+// FoldChainable folds elements into an accumulator of a different type
+func FoldChainable[T any, U any](c *Chainable[T], initial U, f func(U, T) U) U {
+	acc := initial
+	for _, v := range c.data {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// CartesianProduct pairs every element of a with every element of b, in row-major order
+func CartesianProduct[A any, B any](a *Chainable[A], b *Chainable[B]) *ChainablePair[A, B] {
+	result := make([]Pair[A, B], 0, len(a.data)*len(b.data))
+	for _, x := range a.data {
+		for _, y := range b.data {
+			result = append(result, Pair[A, B]{
+				First:  x,
+				Second: y,
+			})
+		}
+	}
+	return &ChainablePair[A, B]{data: result}
+}
+
 // Empty creates an empty chainable
 func EmptyChainable[T any]() *Chainable[T] {
 	return NewChainable([]T{})
@@ -1,7 +1,10 @@
 package rust_test
 
 import (
+	"errors"
 	"fmt"
+	"math/rand"
+	"strconv"
 	"testing"
 
 	. "github.com/dongrv/rust-go"
@@ -442,3 +445,1537 @@ func TestOnceAndRepeat(t *testing.T) {
 		}
 	})
 }
+
+func TestTakeWhileAndSkipWhile(t *testing.T) {
+	t.Run("TakeWhile stops at first failure", func(t *testing.T) {
+		slice := []int{1, 2, 3, 10, 1, 2}
+		result := Collect(TakeWhile(Iter(slice), func(x int) bool { return x < 5 }))
+
+		expected := []int{1, 2, 3}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("TakeWhile stays exhausted", func(t *testing.T) {
+		iter := TakeWhile(Iter([]int{1, 10, 2}), func(x int) bool { return x < 5 })
+		iter.Next()
+		if iter.Next().IsSome() {
+			t.Error("Expected TakeWhile to return None after predicate fails")
+		}
+		if iter.Next().IsSome() {
+			t.Error("Expected TakeWhile to stay exhausted even if later elements pass")
+		}
+	})
+
+	t.Run("SkipWhile", func(t *testing.T) {
+		slice := []int{1, 2, 3, 10, 1, 2}
+		result := Collect(SkipWhile(Iter(slice), func(x int) bool { return x < 5 }))
+
+		expected := []int{10, 1, 2}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("SkipWhile all match", func(t *testing.T) {
+		result := Collect(SkipWhile(Iter([]int{1, 2, 3}), func(x int) bool { return x < 5 }))
+		if len(result) != 0 {
+			t.Errorf("Expected empty result, got %v", result)
+		}
+	})
+}
+
+func TestScan(t *testing.T) {
+	t.Run("Running sum", func(t *testing.T) {
+		slice := []int{1, 2, 3, 4, 5}
+		result := Collect(Scan(Iter(slice), 0, func(acc *int, x int) Option[int] {
+			*acc += x
+			return Some(*acc)
+		}))
+
+		expected := []int{1, 3, 6, 10, 15}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("Stops on None", func(t *testing.T) {
+		slice := []int{1, 2, 3, 4, 5}
+		result := Collect(Scan(Iter(slice), 0, func(acc *int, x int) Option[string] {
+			*acc += x
+			if *acc > 6 {
+				return None[string]()
+			}
+			return Some(fmt.Sprintf("sum-%d", *acc))
+		}))
+
+		expected := []string{"sum-1", "sum-3", "sum-6"}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %s at index %d, got %s", v, i, result[i])
+			}
+		}
+	})
+}
+
+func TestFlatMapIter(t *testing.T) {
+	t.Run("Flattens inner iterators", func(t *testing.T) {
+		slice := []int{1, 2, 3}
+		result := Collect(FlatMapIter(Iter(slice), func(x int) Iterator[int] {
+			return Take(Repeat(x), x)
+		}))
+
+		expected := []int{1, 2, 2, 3, 3, 3}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("Empty inner iterators", func(t *testing.T) {
+		slice := []int{1, 2, 3, 4}
+		result := Collect(FlatMapIter(Iter(slice), func(x int) Iterator[string] {
+			if x%2 == 0 {
+				return Empty[string]()
+			}
+			return Once(fmt.Sprintf("odd-%d", x))
+		}))
+
+		expected := []string{"odd-1", "odd-3"}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %s at index %d, got %s", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("Lazy over infinite source", func(t *testing.T) {
+		result := Collect(Take(FlatMapIter(Repeat(7), func(x int) Iterator[int] {
+			return Iter([]int{x, -x})
+		}), 3))
+
+		expected := []int{7, -7, 7}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+}
+
+func TestDedup(t *testing.T) {
+	t.Run("Dedup consecutive", func(t *testing.T) {
+		slice := []int{1, 1, 2, 2, 2, 1}
+		result := Collect(Dedup(Iter(slice)))
+
+		expected := []int{1, 2, 1}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("DedupBy custom equality", func(t *testing.T) {
+		slice := []string{"apple", "avocado", "banana", "blueberry", "apricot"}
+		result := Collect(DedupBy(Iter(slice), func(a, b string) bool { return a[0] == b[0] }))
+
+		expected := []string{"apple", "banana", "apricot"}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %s at index %d, got %s", v, i, result[i])
+			}
+		}
+	})
+}
+
+func TestMaxMin(t *testing.T) {
+	t.Run("Empty iterator", func(t *testing.T) {
+		if Max(Empty[int]()).IsSome() {
+			t.Error("Expected Max on empty iterator to return None")
+		}
+		if Min(Empty[int]()).IsSome() {
+			t.Error("Expected Min on empty iterator to return None")
+		}
+	})
+
+	t.Run("Single element", func(t *testing.T) {
+		if Max(Once(7)).UnwrapOr(0) != 7 {
+			t.Error("Expected Max of single element to return it")
+		}
+		if Min(Once(7)).UnwrapOr(0) != 7 {
+			t.Error("Expected Min of single element to return it")
+		}
+	})
+
+	t.Run("Multiple elements", func(t *testing.T) {
+		slice := []int{3, 9, -2, 5, 9, -2}
+		if max := Max(Iter(slice)).UnwrapOr(0); max != 9 {
+			t.Errorf("Expected Max 9, got %d", max)
+		}
+		if min := Min(Iter(slice)).UnwrapOr(0); min != -2 {
+			t.Errorf("Expected Min -2, got %d", min)
+		}
+		if max := Max(Iter([]string{"pear", "apple", "zucchini"})).UnwrapOr(""); max != "zucchini" {
+			t.Errorf("Expected Max zucchini, got %s", max)
+		}
+	})
+
+	t.Run("MaxBy and MinBy with ties", func(t *testing.T) {
+		words := []string{"bb", "a", "cc", "d"}
+		byLen := func(a, b string) int { return len(a) - len(b) }
+
+		if max := MaxBy(Iter(words), byLen).UnwrapOr(""); max != "cc" {
+			t.Errorf("Expected MaxBy to return last maximum cc, got %s", max)
+		}
+		if min := MinBy(Iter(words), byLen).UnwrapOr(""); min != "a" {
+			t.Errorf("Expected MinBy to return first minimum a, got %s", min)
+		}
+		if MaxBy(Empty[string](), byLen).IsSome() {
+			t.Error("Expected MaxBy on empty iterator to return None")
+		}
+	})
+}
+
+func TestSumProduct(t *testing.T) {
+	t.Run("Integers", func(t *testing.T) {
+		slice := []int{1, 2, 3, 4, 5}
+		if sum := Sum(Iter(slice)); sum != 15 {
+			t.Errorf("Expected sum 15, got %d", sum)
+		}
+		if product := Product(Iter(slice)); product != 120 {
+			t.Errorf("Expected product 120, got %d", product)
+		}
+	})
+
+	t.Run("Floats", func(t *testing.T) {
+		slice := []float64{0.5, 1.5, 4.0}
+		if sum := Sum(Iter(slice)); sum != 6.0 {
+			t.Errorf("Expected sum 6.0, got %f", sum)
+		}
+		if product := Product(Iter(slice)); product != 3.0 {
+			t.Errorf("Expected product 3.0, got %f", product)
+		}
+	})
+
+	t.Run("Empty iterator", func(t *testing.T) {
+		if sum := Sum(Empty[int]()); sum != 0 {
+			t.Errorf("Expected sum of empty iterator to be 0, got %d", sum)
+		}
+		if product := Product(Empty[float64]()); product != 1 {
+			t.Errorf("Expected product of empty iterator to be 1, got %f", product)
+		}
+	})
+}
+
+func TestPositionAndNth(t *testing.T) {
+	t.Run("Position", func(t *testing.T) {
+		slice := []int{1, 3, 4, 6}
+		pos := Position(Iter(slice), func(x int) bool { return x%2 == 0 })
+		if pos.UnwrapOr(-1) != 2 {
+			t.Errorf("Expected position 2, got %v", pos)
+		}
+	})
+
+	t.Run("Position never matches", func(t *testing.T) {
+		slice := []int{1, 3, 5}
+		pos := Position(Iter(slice), func(x int) bool { return x > 10 })
+		if pos.IsSome() {
+			t.Errorf("Expected None, got %v", pos)
+		}
+	})
+
+	t.Run("Nth", func(t *testing.T) {
+		iter := Iter([]string{"a", "b", "c", "d"})
+		if nth := Nth(iter, 1); nth.UnwrapOr("") != "b" {
+			t.Errorf("Expected b, got %v", nth)
+		}
+		if next := iter.Next(); next.UnwrapOr("") != "c" {
+			t.Errorf("Expected Nth to consume only up to the element, got %v", next)
+		}
+	})
+
+	t.Run("Nth out of range", func(t *testing.T) {
+		if Nth(Iter([]int{1, 2, 3}), 3).IsSome() {
+			t.Error("Expected Nth past the end to return None")
+		}
+		if Nth(Iter([]int{1, 2, 3}), -1).IsSome() {
+			t.Error("Expected Nth with negative index to return None")
+		}
+	})
+}
+
+func TestCycle(t *testing.T) {
+	t.Run("Cycle with Take", func(t *testing.T) {
+		result := Collect(Take(Cycle([]int{1, 2, 3}), 7))
+		expected := []int{1, 2, 3, 1, 2, 3, 1}
+
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("Cycle empty slice", func(t *testing.T) {
+		if Cycle([]int{}).Next().IsSome() {
+			t.Error("Expected Cycle of empty slice to return None")
+		}
+	})
+}
+
+func TestIntersperse(t *testing.T) {
+	t.Run("Empty source", func(t *testing.T) {
+		result := Collect(Intersperse(Empty[string](), ","))
+		if len(result) != 0 {
+			t.Errorf("Expected empty result, got %v", result)
+		}
+	})
+
+	t.Run("Single element", func(t *testing.T) {
+		result := Collect(Intersperse(Once("a"), ","))
+		if len(result) != 1 || result[0] != "a" {
+			t.Errorf("Expected [a], got %v", result)
+		}
+	})
+
+	t.Run("Multiple elements", func(t *testing.T) {
+		result := Collect(Intersperse(Iter([]string{"a", "b", "c"}), ","))
+		expected := []string{"a", ",", "b", ",", "c"}
+
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %s at index %d, got %s", v, i, result[i])
+			}
+		}
+	})
+}
+
+func TestInspectIter(t *testing.T) {
+	t.Run("Only pulled elements are inspected", func(t *testing.T) {
+		var seen []int
+		result := Collect(Take(InspectIter(Iter([]int{1, 2, 3, 4, 5}), func(x int) {
+			seen = append(seen, x)
+		}), 2))
+
+		expected := []int{1, 2}
+		if len(result) != len(expected) || len(seen) != len(expected) {
+			t.Errorf("Expected %d results and inspections, got %v and %v", len(expected), result, seen)
+		}
+		for i, v := range expected {
+			if result[i] != v || seen[i] != v {
+				t.Errorf("Expected %d at index %d, got %d and %d", v, i, result[i], seen[i])
+			}
+		}
+	})
+}
+
+func TestCollectSetAndGroupBy(t *testing.T) {
+	t.Run("CollectSet", func(t *testing.T) {
+		set := CollectSet(Iter([]int{1, 2, 2, 3, 1}))
+		if len(set) != 3 {
+			t.Errorf("Expected 3 distinct elements, got %d", len(set))
+		}
+		for _, v := range []int{1, 2, 3} {
+			if _, ok := set[v]; !ok {
+				t.Errorf("Expected set to contain %d", v)
+			}
+		}
+	})
+
+	t.Run("GroupBy struct field", func(t *testing.T) {
+		type employee struct {
+			Name string
+			Dept string
+		}
+		employees := []employee{
+			{"Alice", "eng"},
+			{"Bob", "sales"},
+			{"Carol", "eng"},
+			{"Dave", "eng"},
+		}
+		groups := GroupBy(Iter(employees), func(e employee) string { return e.Dept })
+
+		if len(groups) != 2 {
+			t.Errorf("Expected 2 groups, got %d", len(groups))
+		}
+		expected := []string{"Alice", "Carol", "Dave"}
+		eng := groups["eng"]
+		if len(eng) != len(expected) {
+			t.Fatalf("Expected %d engineers, got %d", len(expected), len(eng))
+		}
+		for i, name := range expected {
+			if eng[i].Name != name {
+				t.Errorf("Expected %s at index %d, got %s", name, i, eng[i].Name)
+			}
+		}
+		if len(groups["sales"]) != 1 || groups["sales"][0].Name != "Bob" {
+			t.Errorf("Expected sales group [Bob], got %v", groups["sales"])
+		}
+	})
+}
+
+func TestSorted(t *testing.T) {
+	t.Run("Sorted", func(t *testing.T) {
+		result := Sorted(Iter([]int{5, 3, 1, 4, 2}))
+		expected := []int{1, 2, 3, 4, 5}
+
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("SortedBy is stable", func(t *testing.T) {
+		words := []string{"ccc", "a", "bb", "b", "aa", "c"}
+		result := SortedBy(Iter(words), func(a, b string) bool { return len(a) < len(b) })
+		expected := []string{"a", "b", "c", "bb", "aa", "ccc"}
+
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %s at index %d, got %s", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("Sorted empty", func(t *testing.T) {
+		if result := Sorted(Empty[int]()); len(result) != 0 {
+			t.Errorf("Expected empty result, got %v", result)
+		}
+	})
+}
+
+func TestRev(t *testing.T) {
+	t.Run("Reverse order", func(t *testing.T) {
+		result := Collect(Rev(Iter([]int{1, 2, 3})))
+		expected := []int{3, 2, 1}
+
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("Rev empty", func(t *testing.T) {
+		if Rev(Empty[int]()).Next().IsSome() {
+			t.Error("Expected Rev of empty iterator to return None")
+		}
+	})
+}
+
+func TestZip3(t *testing.T) {
+	t.Run("Uneven sources", func(t *testing.T) {
+		names := Iter([]string{"a", "b", "c", "d"})
+		ages := Iter([]int{1, 2})
+		flags := Iter([]bool{true, false, true})
+		result := Collect(Zip3(names, ages, flags))
+
+		expected := []Triple[string, int, bool]{
+			{First: "a", Second: 1, Third: true},
+			{First: "b", Second: 2, Third: false},
+		}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %v at index %d, got %v", v, i, result[i])
+			}
+		}
+	})
+}
+
+func TestTryFoldAndFoldWhile(t *testing.T) {
+	t.Run("TryFold all Ok", func(t *testing.T) {
+		result := TryFold(Iter([]int{1, 2, 3}), 0, func(acc, x int) Result[int, error] {
+			return Ok[int, error](acc + x)
+		})
+		if result.UnwrapOr(0) != 6 {
+			t.Errorf("Expected Ok(6), got %v", result)
+		}
+	})
+
+	t.Run("TryFold aborts on Err", func(t *testing.T) {
+		iter := Iter([]int{1, 2, -1, 4})
+		visited := 0
+		result := TryFold(iter, 0, func(acc, x int) Result[int, error] {
+			visited++
+			if x < 0 {
+				return Err[int, error](errors.New("negative value"))
+			}
+			return Ok[int, error](acc + x)
+		})
+		if !result.IsErr() {
+			t.Fatalf("Expected Err, got %v", result)
+		}
+		if result.UnwrapErr().Error() != "negative value" {
+			t.Errorf("Expected 'negative value', got %v", result.UnwrapErr())
+		}
+		if visited != 3 {
+			t.Errorf("Expected fold to stop after 3 elements, visited %d", visited)
+		}
+		if iter.Next().UnwrapOr(0) != 4 {
+			t.Error("Expected remaining elements to be left in the iterator")
+		}
+	})
+
+	t.Run("FoldWhile stops early", func(t *testing.T) {
+		sum := FoldWhile(Iter([]int{1, 2, 3, 4, 5}), 0, func(acc, x int) (int, bool) {
+			if acc+x > 6 {
+				return acc, false
+			}
+			return acc + x, true
+		})
+		if sum != 6 {
+			t.Errorf("Expected sum 6, got %d", sum)
+		}
+	})
+
+	t.Run("FoldWhile runs to completion", func(t *testing.T) {
+		sum := FoldWhile(Iter([]int{1, 2, 3}), 0, func(acc, x int) (int, bool) {
+			return acc + x, true
+		})
+		if sum != 6 {
+			t.Errorf("Expected sum 6, got %d", sum)
+		}
+	})
+}
+
+func TestFlattenSlices(t *testing.T) {
+	t.Run("Flatten with empty slice", func(t *testing.T) {
+		result := Collect(FlattenSlices(Iter([][]int{{1, 2}, {}, {3}})))
+		expected := []int{1, 2, 3}
+
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("Flatten chunks", func(t *testing.T) {
+		chunks := From([]int{1, 2, 3, 4, 5}).Chunk(2).Collect()
+		result := Collect(FlattenSlices(Iter(chunks)))
+
+		if len(result) != 5 || result[4] != 5 {
+			t.Errorf("Expected flattened chunks to restore the original slice, got %v", result)
+		}
+	})
+}
+
+func TestChainMany(t *testing.T) {
+	t.Run("Chain four iterators with empties", func(t *testing.T) {
+		result := Collect(ChainMany(
+			Iter([]int{1, 2}),
+			Empty[int](),
+			Iter([]int{}),
+			Iter([]int{3, 4}),
+		))
+		expected := []int{1, 2, 3, 4}
+
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("No iterators", func(t *testing.T) {
+		if ChainMany[int]().Next().IsSome() {
+			t.Error("Expected ChainMany with no iterators to return None")
+		}
+	})
+}
+
+func TestTallyAndCountBy(t *testing.T) {
+	t.Run("Tally", func(t *testing.T) {
+		words := []string{"go", "rust", "go", "zig", "go", "rust"}
+		counts := Tally(Iter(words))
+
+		expected := map[string]int{"go": 3, "rust": 2, "zig": 1}
+		if len(counts) != len(expected) {
+			t.Errorf("Expected %d distinct words, got %d", len(expected), len(counts))
+		}
+		for word, n := range expected {
+			if counts[word] != n {
+				t.Errorf("Expected %s to appear %d times, got %d", word, n, counts[word])
+			}
+		}
+	})
+
+	t.Run("CountBy", func(t *testing.T) {
+		counts := CountBy(Iter([]int{1, 2, 3, 4, 5, 6, 7}), func(x int) bool { return x%2 == 0 })
+		if counts[true] != 3 || counts[false] != 4 {
+			t.Errorf("Expected 3 even and 4 odd, got %v", counts)
+		}
+	})
+}
+
+func TestFromChannel(t *testing.T) {
+	t.Run("Collect from producer goroutine", func(t *testing.T) {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for i := 1; i <= 5; i++ {
+				ch <- i
+			}
+		}()
+
+		result := Collect(FromChannel(ch))
+		expected := []int{1, 2, 3, 4, 5}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+}
+
+func TestToChannel(t *testing.T) {
+	t.Run("All elements arrive and channel closes", func(t *testing.T) {
+		ch := ToChannel(Iter([]int{1, 2, 3}), 1)
+
+		var result []int
+		for v := range ch {
+			result = append(result, v)
+		}
+		expected := []int{1, 2, 3}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+		if _, ok := <-ch; ok {
+			t.Error("Expected channel to be closed")
+		}
+	})
+
+	t.Run("Round trip through FromChannel", func(t *testing.T) {
+		sum := Sum(FromChannel(ToChannel(Range(1, 11, 1), 0)))
+		if sum != 55 {
+			t.Errorf("Expected sum 55, got %d", sum)
+		}
+	})
+}
+
+func TestParMap(t *testing.T) {
+	t.Run("Preserves order", func(t *testing.T) {
+		source := Collect(Range(0, 1000, 1))
+		result := ParMap(source, 8, func(x int) int { return x * x })
+
+		if len(result) != len(source) {
+			t.Fatalf("Expected length %d, got %d", len(source), len(result))
+		}
+		for i, v := range source {
+			if result[i] != v*v {
+				t.Errorf("Expected %d at index %d, got %d", v*v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("Default workers and type change", func(t *testing.T) {
+		result := ParMap([]int{1, 2, 3}, 0, func(x int) string { return fmt.Sprintf("n%d", x) })
+		expected := []string{"n1", "n2", "n3"}
+
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %s at index %d, got %s", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("Empty source", func(t *testing.T) {
+		if result := ParMap([]int{}, 4, func(x int) int { return x }); len(result) != 0 {
+			t.Errorf("Expected empty result, got %v", result)
+		}
+	})
+}
+
+func TestIterateAndUnfold(t *testing.T) {
+	t.Run("Iterate powers of two", func(t *testing.T) {
+		result := Collect(Take(Iterate(1, func(x int) int { return x * 2 }), 5))
+		expected := []int{1, 2, 4, 8, 16}
+
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("Unfold Fibonacci", func(t *testing.T) {
+		fib := Unfold(Pair[int, int]{First: 0, Second: 1}, func(s Pair[int, int]) Option[Pair[int, Pair[int, int]]] {
+			next := Pair[int, int]{First: s.Second, Second: s.First + s.Second}
+			return Some(Pair[int, Pair[int, int]]{First: s.First, Second: next})
+		})
+		result := Collect(Take(fib, 10))
+		expected := []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34}
+
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("Unfold terminates on None", func(t *testing.T) {
+		countdown := Unfold(3, func(n int) Option[Pair[int, int]] {
+			if n == 0 {
+				return None[Pair[int, int]]()
+			}
+			return Some(Pair[int, int]{First: n, Second: n - 1})
+		})
+		result := Collect(countdown)
+		if len(result) != 3 || result[0] != 3 || result[2] != 1 {
+			t.Errorf("Expected [3 2 1], got %v", result)
+		}
+	})
+}
+
+func TestIterMap(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	t.Run("IterKeys", func(t *testing.T) {
+		keys := Tally(IterKeys(m))
+		if len(keys) != len(m) {
+			t.Errorf("Expected %d keys, got %d", len(m), len(keys))
+		}
+		for k := range m {
+			if keys[k] != 1 {
+				t.Errorf("Expected key %s to be visited once, got %d", k, keys[k])
+			}
+		}
+	})
+
+	t.Run("IterValues", func(t *testing.T) {
+		values := Sorted(IterValues(m))
+		expected := []int{1, 2, 3}
+		if len(values) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(values))
+		}
+		for i, v := range expected {
+			if values[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, values[i])
+			}
+		}
+	})
+
+	t.Run("IterEntries", func(t *testing.T) {
+		seen := make(map[string]int)
+		ForEach(IterEntries(m), func(p Pair[string, int]) {
+			if m[p.First] != p.Second {
+				t.Errorf("Expected entry %s=%d, got %d", p.First, m[p.First], p.Second)
+			}
+			seen[p.First]++
+		})
+		if len(seen) != len(m) {
+			t.Errorf("Expected %d entries, got %d", len(m), len(seen))
+		}
+		for k, n := range seen {
+			if n != 1 {
+				t.Errorf("Expected entry %s to be visited once, got %d", k, n)
+			}
+		}
+	})
+}
+
+func TestMaxByKeyAndMinByKey(t *testing.T) {
+	type product struct {
+		Name  string
+		Sales int
+	}
+	products := []product{
+		{"widget", 30},
+		{"gadget", 50},
+		{"gizmo", 10},
+		{"doohickey", 50},
+		{"thing", 10},
+	}
+	sales := func(p product) int { return p.Sales }
+
+	t.Run("MaxByKey first tie wins", func(t *testing.T) {
+		top := MaxByKey(Iter(products), sales)
+		if top.IsNone() || top.Unwrap().Name != "gadget" {
+			t.Errorf("Expected gadget, got %v", top)
+		}
+	})
+
+	t.Run("MinByKey first tie wins", func(t *testing.T) {
+		bottom := MinByKey(Iter(products), sales)
+		if bottom.IsNone() || bottom.Unwrap().Name != "gizmo" {
+			t.Errorf("Expected gizmo, got %v", bottom)
+		}
+	})
+
+	t.Run("Empty input", func(t *testing.T) {
+		if MaxByKey(Empty[product](), sales).IsSome() {
+			t.Error("Expected MaxByKey on empty iterator to return None")
+		}
+		if MinByKey(Empty[product](), sales).IsSome() {
+			t.Error("Expected MinByKey on empty iterator to return None")
+		}
+	})
+}
+
+func TestMaxMinTies(t *testing.T) {
+	type entry struct {
+		Name  string
+		Score int
+	}
+	entries := []entry{
+		{"a", 1},
+		{"b", 3},
+		{"c", 1},
+		{"d", 3},
+	}
+	byScore := func(x, y entry) int { return x.Score - y.Score }
+	score := func(e entry) int { return e.Score }
+
+	// MaxBy returns the last of equal elements, the Min family the first
+	maxes := map[string]Option[entry]{
+		"MaxBy":           MaxBy(Iter(entries), byScore),
+		"Chainable.MaxBy": NewChainable(entries).MaxBy(byScore),
+	}
+	for name, got := range maxes {
+		if got.IsNone() || got.Unwrap().Name != "d" {
+			t.Errorf("%s: expected d, got %v", name, got)
+		}
+	}
+
+	mins := map[string]Option[entry]{
+		"MinBy":           MinBy(Iter(entries), byScore),
+		"MinByKey":        MinByKey(Iter(entries), score),
+		"Chainable.MinBy": NewChainable(entries).MinBy(byScore),
+	}
+	for name, got := range mins {
+		if got.IsNone() || got.Unwrap().Name != "a" {
+			t.Errorf("%s: expected a, got %v", name, got)
+		}
+	}
+}
+
+func TestFindMap(t *testing.T) {
+	t.Run("First even number transformed", func(t *testing.T) {
+		iter := Iter([]int{1, 3, 4, 5, 6})
+		result := FindMap(iter, func(x int) Option[string] {
+			if x%2 == 0 {
+				return Some(fmt.Sprintf("even-%d", x))
+			}
+			return None[string]()
+		})
+		if result.UnwrapOr("") != "even-4" {
+			t.Errorf("Expected even-4, got %v", result)
+		}
+		if iter.Next().UnwrapOr(0) != 5 {
+			t.Error("Expected FindMap to stop after the first match")
+		}
+	})
+
+	t.Run("No match", func(t *testing.T) {
+		result := FindMap(Iter([]int{1, 3}), func(x int) Option[int] { return None[int]() })
+		if result.IsSome() {
+			t.Errorf("Expected None, got %v", result)
+		}
+	})
+}
+
+func TestAverage(t *testing.T) {
+	t.Run("Integers", func(t *testing.T) {
+		avg := Average(Iter([]int{1, 2, 3, 4}))
+		if avg.UnwrapOr(0) != 2.5 {
+			t.Errorf("Expected 2.5, got %v", avg)
+		}
+	})
+
+	t.Run("Floats", func(t *testing.T) {
+		avg := Average(Iter([]float64{1.5, 2.5, 5.0}))
+		if avg.UnwrapOr(0) != 3.0 {
+			t.Errorf("Expected 3.0, got %v", avg)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if Average(Empty[int]()).IsSome() {
+			t.Error("Expected Average on empty iterator to return None")
+		}
+	})
+}
+
+func TestTryCollect(t *testing.T) {
+	parse := func(s string) Result[int, string] {
+		var n int
+		if _, err := fmt.Sscanf(s, "%d", &n); err != nil {
+			return Err[int, string]("invalid: " + s)
+		}
+		return Ok[int, string](n)
+	}
+
+	t.Run("All Ok", func(t *testing.T) {
+		result := TryCollect(Map(Iter([]string{"1", "2", "3"}), parse))
+		if !result.IsOk() {
+			t.Fatalf("Expected Ok, got %v", result)
+		}
+		values := result.Unwrap()
+		expected := []int{1, 2, 3}
+		if len(values) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(values))
+		}
+		for i, v := range expected {
+			if values[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, values[i])
+			}
+		}
+	})
+
+	t.Run("Early Err", func(t *testing.T) {
+		source := Iter([]string{"1", "x", "3", "y"})
+		result := TryCollect(Map(source, parse))
+		if !result.IsErr() {
+			t.Fatalf("Expected Err, got %v", result)
+		}
+		if result.UnwrapErr() != "invalid: x" {
+			t.Errorf("Expected first error 'invalid: x', got %v", result.UnwrapErr())
+		}
+		if source.Next().UnwrapOr("") != "3" {
+			t.Error("Expected TryCollect to stop at the first Err")
+		}
+	})
+}
+
+func TestChainableSort(t *testing.T) {
+	t.Run("SortChainable", func(t *testing.T) {
+		slice := []int{5, 3, 1, 4, 2}
+		result := SortChainable(From(slice)).Collect()
+
+		expected := []int{1, 2, 3, 4, 5}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+		if slice[0] != 5 {
+			t.Error("Expected original slice to be unchanged")
+		}
+	})
+
+	t.Run("SortBy descending", func(t *testing.T) {
+		result := From([]int{2, 9, 4, 7}).
+			SortBy(func(a, b int) bool { return a > b }).
+			Collect()
+
+		expected := []int{9, 7, 4, 2}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("SortStableBy keeps equal elements in order", func(t *testing.T) {
+		type item struct {
+			Name     string
+			Priority int
+		}
+		items := []item{{"a", 2}, {"b", 1}, {"c", 2}, {"d", 1}, {"e", 3}}
+		result := From(items).
+			SortStableBy(func(x, y item) bool { return x.Priority > y.Priority }).
+			Collect()
+
+		expected := []string{"e", "a", "c", "b", "d"}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i].Name != v {
+				t.Errorf("Expected %s at index %d, got %s", v, i, result[i].Name)
+			}
+		}
+		if items[0].Name != "a" || items[4].Name != "e" {
+			t.Error("Expected original slice to be unchanged")
+		}
+	})
+}
+
+func TestChainableGroupBy(t *testing.T) {
+	type order struct {
+		ID     int
+		UserID string
+	}
+	orders := []order{
+		{1, "alice"},
+		{2, "bob"},
+		{3, "alice"},
+		{4, "carol"},
+		{5, "alice"},
+		{6, "bob"},
+	}
+
+	groups := GroupByChainable(From(orders), func(o order) string { return o.UserID })
+	if len(groups) != 3 {
+		t.Errorf("Expected 3 groups, got %d", len(groups))
+	}
+
+	expected := map[string][]int{
+		"alice": {1, 3, 5},
+		"bob":   {2, 6},
+		"carol": {4},
+	}
+	for user, ids := range expected {
+		bucket := groups[user]
+		if len(bucket) != len(ids) {
+			t.Errorf("Expected %d orders for %s, got %d", len(ids), user, len(bucket))
+			continue
+		}
+		for i, id := range ids {
+			if bucket[i].ID != id {
+				t.Errorf("Expected order %d at index %d for %s, got %d", id, i, user, bucket[i].ID)
+			}
+		}
+	}
+}
+
+func TestChainableCount(t *testing.T) {
+	slice := []int{1, 2, 3, 4, 5, 6}
+
+	t.Run("Count", func(t *testing.T) {
+		if n := From(slice).Count(func(x int) bool { return x > 10 }); n != 0 {
+			t.Errorf("Expected 0 matches, got %d", n)
+		}
+		if n := From(slice).Count(func(x int) bool { return x > 0 }); n != 6 {
+			t.Errorf("Expected 6 matches, got %d", n)
+		}
+		if n := From(slice).Count(func(x int) bool { return x%3 == 0 }); n != 2 {
+			t.Errorf("Expected 2 matches, got %d", n)
+		}
+	})
+
+	t.Run("CountByChainable", func(t *testing.T) {
+		words := []string{"apple", "avocado", "banana", "blueberry", "cherry", "apricot"}
+		counts := CountByChainable(From(words), func(s string) byte { return s[0] })
+
+		expected := map[byte]int{'a': 3, 'b': 2, 'c': 1}
+		if len(counts) != len(expected) {
+			t.Errorf("Expected %d keys, got %d", len(expected), len(counts))
+		}
+		for k, n := range expected {
+			if counts[k] != n {
+				t.Errorf("Expected %c to appear %d times, got %d", k, n, counts[k])
+			}
+		}
+	})
+}
+
+func TestChainableMinMax(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		empty := EmptyChainable[int]()
+		if MinChainable(empty).IsSome() || MaxChainable(empty).IsSome() {
+			t.Error("Expected Min/Max on empty chainable to return None")
+		}
+		cmp := func(a, b int) int { return a - b }
+		if empty.MinBy(cmp).IsSome() || empty.MaxBy(cmp).IsSome() {
+			t.Error("Expected MinBy/MaxBy on empty chainable to return None")
+		}
+	})
+
+	t.Run("MinChainable and MaxChainable", func(t *testing.T) {
+		c := Of(4, -1, 9, 3)
+		if min := MinChainable(c).UnwrapOr(0); min != -1 {
+			t.Errorf("Expected min -1, got %d", min)
+		}
+		if max := MaxChainable(c).UnwrapOr(0); max != 9 {
+			t.Errorf("Expected max 9, got %d", max)
+		}
+	})
+
+	t.Run("MinBy and MaxBy with ties", func(t *testing.T) {
+		c := Of("bb", "a", "cc", "d")
+		byLen := func(a, b string) int { return len(a) - len(b) }
+		if min := c.MinBy(byLen).UnwrapOr(""); min != "a" {
+			t.Errorf("Expected first minimum a, got %s", min)
+		}
+		if max := c.MaxBy(byLen).UnwrapOr(""); max != "cc" {
+			t.Errorf("Expected last maximum cc, got %s", max)
+		}
+	})
+}
+
+func TestChainableSumAverage(t *testing.T) {
+	t.Run("Integers", func(t *testing.T) {
+		c := Of(1, 2, 3, 4)
+		if sum := SumChainable(c); sum != 10 {
+			t.Errorf("Expected sum 10, got %d", sum)
+		}
+		if avg := AverageChainable(c).UnwrapOr(0); avg != 2.5 {
+			t.Errorf("Expected average 2.5, got %f", avg)
+		}
+	})
+
+	t.Run("Floats", func(t *testing.T) {
+		prices := Of(19.5, 5.25, 0.25)
+		if sum := SumChainable(prices); sum != 25.0 {
+			t.Errorf("Expected sum 25.0, got %f", sum)
+		}
+		if avg := AverageChainable(prices).UnwrapOr(0); avg < 8.33 || avg > 8.34 {
+			t.Errorf("Expected average ~8.33, got %f", avg)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if SumChainable(EmptyChainable[int]()) != 0 {
+			t.Error("Expected sum of empty chainable to be 0")
+		}
+		if AverageChainable(EmptyChainable[float64]()).IsSome() {
+			t.Error("Expected average of empty chainable to be None")
+		}
+	})
+}
+
+func TestChainableContainsIndexOf(t *testing.T) {
+	c := Of("a", "b", "c", "b")
+
+	t.Run("Present", func(t *testing.T) {
+		if !ContainsChainable(c, "c") {
+			t.Error("Expected chainable to contain c")
+		}
+		if idx := IndexOfChainable(c, "b"); idx.UnwrapOr(-1) != 1 {
+			t.Errorf("Expected first occurrence at index 1, got %v", idx)
+		}
+	})
+
+	t.Run("Absent", func(t *testing.T) {
+		if ContainsChainable(c, "z") {
+			t.Error("Expected chainable not to contain z")
+		}
+		if idx := IndexOfChainable(c, "z"); idx.IsSome() {
+			t.Errorf("Expected None, got %v", idx)
+		}
+		if ContainsChainable(EmptyChainable[string](), "a") {
+			t.Error("Expected empty chainable not to contain anything")
+		}
+	})
+}
+
+func TestChainableTakeWhileSkipWhile(t *testing.T) {
+	slice := []int{1, 2, 3, 10, 1, 2}
+	lessThanFive := func(x int) bool { return x < 5 }
+
+	t.Run("TakeWhile stops at first failure", func(t *testing.T) {
+		result := From(slice).TakeWhile(lessThanFive).Collect()
+
+		expected := []int{1, 2, 3}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("SkipWhile", func(t *testing.T) {
+		result := From(slice).SkipWhile(lessThanFive).Collect()
+
+		expected := []int{10, 1, 2}
+		if len(result) != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), len(result))
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	})
+
+	t.Run("All match", func(t *testing.T) {
+		if n := len(Of(1, 2).TakeWhile(lessThanFive).Collect()); n != 2 {
+			t.Errorf("Expected TakeWhile to keep all 2 elements, got %d", n)
+		}
+		if n := len(Of(1, 2).SkipWhile(lessThanFive).Collect()); n != 0 {
+			t.Errorf("Expected SkipWhile to drop all elements, got %d", n)
+		}
+	})
+}
+
+func TestMapChainable(t *testing.T) {
+	result := MapChainable(Of(1, 2, 3), func(x int) string {
+		return fmt.Sprintf("#%d", x)
+	}).Collect()
+
+	expected := []string{"#1", "#2", "#3"}
+	if len(result) != len(expected) {
+		t.Errorf("Expected length %d, got %d", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if result[i] != v {
+			t.Errorf("Expected %s at index %d, got %s", v, i, result[i])
+		}
+	}
+}
+
+func TestFilterMapChainable(t *testing.T) {
+	parse := func(s string) Option[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return None[int]()
+		}
+		return Some(n)
+	}
+	result := FilterMapChainable(Of("1", "two", "3", "", "5"), parse).Collect()
+
+	expected := []int{1, 3, 5}
+	if len(result) != len(expected) {
+		t.Errorf("Expected length %d, got %d", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if result[i] != v {
+			t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+		}
+	}
+}
+
+func TestChainableToMapToSet(t *testing.T) {
+	t.Run("ToMapChainable last wins", func(t *testing.T) {
+		words := Of("apple", "banana", "avocado")
+		m := ToMapChainable(words, func(s string) (byte, string) { return s[0], s })
+
+		if len(m) != 2 {
+			t.Errorf("Expected 2 keys, got %d", len(m))
+		}
+		if m['a'] != "avocado" {
+			t.Errorf("Expected last value avocado for key a, got %s", m['a'])
+		}
+		if m['b'] != "banana" {
+			t.Errorf("Expected banana for key b, got %s", m['b'])
+		}
+	})
+
+	t.Run("ToSetChainable", func(t *testing.T) {
+		set := ToSetChainable(Of(3, 1, 3, 2, 1))
+		if len(set) != 3 {
+			t.Errorf("Expected 3 distinct elements, got %d", len(set))
+		}
+		for _, v := range []int{1, 2, 3} {
+			if _, ok := set[v]; !ok {
+				t.Errorf("Expected set to contain %d", v)
+			}
+		}
+	})
+}
+
+func TestUniqueBy(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := []user{
+		{1, "alice"},
+		{2, "bob"},
+		{1, "alice (duplicate)"},
+		{3, "carol"},
+		{2, "bob (duplicate)"},
+	}
+	result := UniqueBy(From(users), func(u user) int { return u.ID }).Collect()
+
+	expected := []string{"alice", "bob", "carol"}
+	if len(result) != len(expected) {
+		t.Errorf("Expected length %d, got %d", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if result[i].Name != v {
+			t.Errorf("Expected %s at index %d, got %s", v, i, result[i].Name)
+		}
+	}
+}
+
+func TestChainableFirstLastNth(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		empty := EmptyChainable[int]()
+		if empty.First().IsSome() || empty.Last().IsSome() || empty.Nth(0).IsSome() {
+			t.Error("Expected First, Last and Nth on empty chainable to return None")
+		}
+	})
+
+	t.Run("In range", func(t *testing.T) {
+		c := Of(10, 20, 30)
+		if first := c.First().UnwrapOr(0); first != 10 {
+			t.Errorf("Expected first 10, got %d", first)
+		}
+		if last := c.Last().UnwrapOr(0); last != 30 {
+			t.Errorf("Expected last 30, got %d", last)
+		}
+		if nth := c.Nth(1).UnwrapOr(0); nth != 20 {
+			t.Errorf("Expected nth(1) 20, got %d", nth)
+		}
+	})
+
+	t.Run("Out of range", func(t *testing.T) {
+		c := Of(10, 20, 30)
+		if c.Nth(3).IsSome() || c.Nth(-1).IsSome() {
+			t.Error("Expected Nth out of range to return None")
+		}
+	})
+}
+
+func TestFoldChainable(t *testing.T) {
+	joined := FoldChainable(Of(1, 2, 3), "", func(acc string, x int) string {
+		if acc == "" {
+			return strconv.Itoa(x)
+		}
+		return acc + "," + strconv.Itoa(x)
+	})
+	if joined != "1,2,3" {
+		t.Errorf("Expected 1,2,3, got %s", joined)
+	}
+
+	empty := FoldChainable(EmptyChainable[int](), "init", func(acc string, x int) string { return acc + "!" })
+	if empty != "init" {
+		t.Errorf("Expected fold of empty chainable to return the initial value, got %s", empty)
+	}
+}
+
+func TestChainableForEachIndexedAndTap(t *testing.T) {
+	t.Run("ForEachIndexed", func(t *testing.T) {
+		var indices []int
+		var values []string
+		Of("a", "b", "c").ForEachIndexed(func(i int, s string) {
+			indices = append(indices, i)
+			values = append(values, s)
+		})
+
+		expected := []string{"a", "b", "c"}
+		if len(indices) != len(expected) {
+			t.Fatalf("Expected %d calls, got %d", len(expected), len(indices))
+		}
+		for i, v := range expected {
+			if indices[i] != i || values[i] != v {
+				t.Errorf("Expected (%d, %s), got (%d, %s)", i, v, indices[i], values[i])
+			}
+		}
+	})
+
+	t.Run("Tap leaves data unchanged", func(t *testing.T) {
+		var tapped []int
+		result := Of(1, 2, 3, 4).
+			Filter(func(x int) bool { return x%2 == 0 }).
+			Tap(func(data []int) { tapped = append(tapped, data...) }).
+			Map(func(x int) int { return x * 10 }).
+			Collect()
+
+		if len(tapped) != 2 || tapped[0] != 2 || tapped[1] != 4 {
+			t.Errorf("Expected Tap to see [2 4], got %v", tapped)
+		}
+		if len(result) != 2 || result[0] != 20 || result[1] != 40 {
+			t.Errorf("Expected [20 40], got %v", result)
+		}
+	})
+}
+
+func TestChainableInsertAtRemoveAt(t *testing.T) {
+	assertSlice := func(t *testing.T, result, expected []int) {
+		t.Helper()
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+		for i, v := range expected {
+			if result[i] != v {
+				t.Errorf("Expected %d at index %d, got %d", v, i, result[i])
+			}
+		}
+	}
+
+	t.Run("InsertAt start, middle and end", func(t *testing.T) {
+		c := Of(1, 2, 3)
+		assertSlice(t, c.InsertAt(0, 9).Collect(), []int{9, 1, 2, 3})
+		assertSlice(t, c.InsertAt(1, 8, 9).Collect(), []int{1, 8, 9, 2, 3})
+		assertSlice(t, c.InsertAt(3, 9).Collect(), []int{1, 2, 3, 9})
+		assertSlice(t, c.Collect(), []int{1, 2, 3})
+	})
+
+	t.Run("RemoveAt", func(t *testing.T) {
+		c := Of(1, 2, 3)
+		assertSlice(t, c.RemoveAt(0).Collect(), []int{2, 3})
+		assertSlice(t, c.RemoveAt(1).Collect(), []int{1, 3})
+		assertSlice(t, c.RemoveAt(2).Collect(), []int{1, 2})
+		assertSlice(t, c.Collect(), []int{1, 2, 3})
+	})
+
+	t.Run("Out of range panics", func(t *testing.T) {
+		mustPanic := func(name string, f func()) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected %s to panic", name)
+				}
+			}()
+			f()
+		}
+		c := Of(1, 2, 3)
+		mustPanic("InsertAt(4)", func() { c.InsertAt(4, 0) })
+		mustPanic("InsertAt(-1)", func() { c.InsertAt(-1, 0) })
+		mustPanic("RemoveAt(3)", func() { c.RemoveAt(3) })
+		mustPanic("RemoveAt on empty", func() { EmptyChainable[int]().RemoveAt(0) })
+	})
+}
+
+func TestChainableShuffleSample(t *testing.T) {
+	data := Collect(Range(0, 20, 1))
+
+	t.Run("Shuffle preserves elements", func(t *testing.T) {
+		shuffled := From(data).Shuffle(rand.New(rand.NewSource(1))).Collect()
+		if len(shuffled) != len(data) {
+			t.Fatalf("Expected length %d, got %d", len(data), len(shuffled))
+		}
+		sorted := SortChainable(From(shuffled)).Collect()
+		for i, v := range data {
+			if sorted[i] != v {
+				t.Errorf("Expected shuffled data to contain %d", v)
+			}
+		}
+		if data[0] != 0 || data[19] != 19 {
+			t.Error("Expected original slice to be unchanged")
+		}
+	})
+
+	t.Run("Shuffle is reproducible", func(t *testing.T) {
+		a := From(data).Shuffle(rand.New(rand.NewSource(42))).Collect()
+		b := From(data).Shuffle(rand.New(rand.NewSource(42))).Collect()
+		for i := range a {
+			if a[i] != b[i] {
+				t.Fatalf("Expected identical shuffles for the same seed, got %v and %v", a, b)
+			}
+		}
+	})
+
+	t.Run("Sample without replacement", func(t *testing.T) {
+		sample := From(data).Sample(5, rand.New(rand.NewSource(7))).Collect()
+		if len(sample) != 5 {
+			t.Fatalf("Expected 5 elements, got %d", len(sample))
+		}
+		seen := ToSetChainable(From(sample))
+		if len(seen) != 5 {
+			t.Errorf("Expected 5 distinct elements, got %v", sample)
+		}
+		for _, v := range sample {
+			if v < 0 || v >= 20 {
+				t.Errorf("Sampled element %d not in source", v)
+			}
+		}
+
+		again := From(data).Sample(5, rand.New(rand.NewSource(7))).Collect()
+		for i := range sample {
+			if sample[i] != again[i] {
+				t.Fatalf("Expected identical samples for the same seed, got %v and %v", sample, again)
+			}
+		}
+	})
+
+	t.Run("Sample bounds", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		if n := len(Of(1, 2, 3).Sample(10, rng).Collect()); n != 3 {
+			t.Errorf("Expected sample size clamped to 3, got %d", n)
+		}
+		if n := len(Of(1, 2, 3).Sample(0, rng).Collect()); n != 0 {
+			t.Errorf("Expected empty sample, got %d elements", n)
+		}
+	})
+}
+
+func TestCartesianProduct(t *testing.T) {
+	result := CartesianProduct(Of(1, 2), Of("x", "y", "z")).Collect()
+
+	expected := []Pair[int, string]{
+		{1, "x"}, {1, "y"}, {1, "z"},
+		{2, "x"}, {2, "y"}, {2, "z"},
+	}
+	if len(result) != 2*3 {
+		t.Errorf("Expected %d pairs, got %d", 2*3, len(result))
+	}
+	for i, v := range expected {
+		if result[i] != v {
+			t.Errorf("Expected %v at index %d, got %v", v, i, result[i])
+		}
+	}
+
+	if n := len(CartesianProduct(Of(1, 2), EmptyChainable[string]()).Collect()); n != 0 {
+		t.Errorf("Expected empty product, got %d pairs", n)
+	}
+}
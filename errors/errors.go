@@ -4,9 +4,15 @@
 package errors
 
 import (
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"io"
 	"runtime"
 	"strings"
+	"time"
+
+	rust "github.com/dongrv/rust-go"
 )
 
 // Error is an enhanced error type that supports chaining, context, and structured error information.
@@ -22,6 +28,47 @@ type Error struct {
 
 	// Context contains additional structured context about the error
 	Context map[string]interface{}
+
+	// Code is an optional machine-readable error code, e.g. for API responses
+	Code string
+
+	// Severity is the importance of the error; the zero value means unset
+	Severity Severity
+}
+
+// Severity ranks how important an error is.
+// Levels compare in increasing order, so filters can use >=.
+type Severity int
+
+const (
+	// SeverityDebug is for diagnostic errors that are normally suppressed
+	SeverityDebug Severity = iota + 1
+	// SeverityInfo is for expected errors worth noting
+	SeverityInfo
+	// SeverityWarn is for recoverable problems
+	SeverityWarn
+	// SeverityError is for failures; errors without a severity are treated as this level
+	SeverityError
+	// SeverityFatal is for failures the program cannot continue from
+	SeverityFatal
+)
+
+// String returns the name of the severity level.
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "DEBUG"
+	case SeverityInfo:
+		return "INFO"
+	case SeverityWarn:
+		return "WARN"
+	case SeverityError:
+		return "ERROR"
+	case SeverityFatal:
+		return "FATAL"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
 }
 
 // New creates a new error with the given message.
@@ -91,6 +138,33 @@ func (e *Error) WithContextMap(context map[string]interface{}) *Error {
 	return e
 }
 
+// WithCause sets the underlying error, so Unwrap, Is and As follow it.
+// Unlike Wrap, the message is left unchanged.
+func (e *Error) WithCause(err error) *Error {
+	e.Cause = err
+	return e
+}
+
+// GetContext returns the context value stored under key on this error.
+func (e *Error) GetContext(key string) rust.Option[interface{}] {
+	if value, ok := e.Context[key]; ok {
+		return rust.Some(value)
+	}
+	return rust.None[interface{}]()
+}
+
+// WithCode sets a machine-readable code on the error.
+func (e *Error) WithCode(code string) *Error {
+	e.Code = code
+	return e
+}
+
+// WithSeverity sets the severity of the error.
+func (e *Error) WithSeverity(s Severity) *Error {
+	e.Severity = s
+	return e
+}
+
 // Error returns the error message.
 func (e *Error) Error() string {
 	return e.Message
@@ -127,6 +201,11 @@ func (e *Error) String() string {
 	var sb strings.Builder
 	sb.WriteString(e.Error())
 
+	if e.Code != "" {
+		sb.WriteString("\nCode: ")
+		sb.WriteString(e.Code)
+	}
+
 	if len(e.Context) > 0 {
 		sb.WriteString("\nContext:")
 		for k, v := range e.Context {
@@ -142,6 +221,71 @@ func (e *Error) String() string {
 	return sb.String()
 }
 
+// Format implements fmt.Formatter.
+// %s and %v print the message, %q prints it quoted, and %+v prints the
+// detailed form from String, including code, context and stack trace.
+func (e *Error) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			io.WriteString(f, e.String())
+			return
+		}
+		io.WriteString(f, e.Error())
+	case 's':
+		io.WriteString(f, e.Error())
+	case 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+	default:
+		fmt.Fprintf(f, "%%!%c(*errors.Error=%s)", verb, e.Error())
+	}
+}
+
+// JSONOptions controls which optional fields MarshalJSONWith includes.
+type JSONOptions struct {
+	// IncludeStack adds the stack frames as "function file:line" strings
+	IncludeStack bool
+}
+
+// errorJSON is the JSON shape of an Error
+type errorJSON struct {
+	Message string                 `json:"message"`
+	Code    string                 `json:"code,omitempty"`
+	Context map[string]interface{} `json:"context,omitempty"`
+	Cause   string                 `json:"cause,omitempty"`
+	Stack   []string               `json:"stack,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// It produces message, code, context and cause; stack frames are omitted.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return e.MarshalJSONWith(JSONOptions{})
+}
+
+// MarshalJSONWith marshals the error like MarshalJSON, with optional fields
+// controlled by opts.
+func (e *Error) MarshalJSONWith(opts JSONOptions) ([]byte, error) {
+	out := errorJSON{
+		Message: e.Message,
+		Code:    e.Code,
+		Context: e.Context,
+	}
+	if e.Cause != nil {
+		out.Cause = e.Cause.Error()
+	}
+	if opts.IncludeStack && len(e.Stack) > 0 {
+		frames := runtime.CallersFrames(e.Stack)
+		for {
+			frame, more := frames.Next()
+			out.Stack = append(out.Stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+			if !more {
+				break
+			}
+		}
+	}
+	return json.Marshal(out)
+}
+
 // Result is a type alias for functions that return a value and an error.
 // It enables functional error handling patterns.
 type Result[T any] struct {
@@ -191,6 +335,48 @@ func (r Result[T]) OrElse(f func(error) Result[T]) Result[T] {
 	return f(r.err)
 }
 
+// MapResultTo applies a function to the value if the Result is Ok,
+// allowing the value type to change.
+func MapResultTo[T any, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(f(r.value))
+}
+
+// AndThenTo chains an operation that returns a Result of a different type.
+func AndThenTo[T any, U any](r Result[T], f func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return f(r.value)
+}
+
+// Inspect calls f with the value if the Result is Ok and returns the Result unchanged.
+func (r Result[T]) Inspect(f func(T)) Result[T] {
+	if r.err == nil {
+		f(r.value)
+	}
+	return r
+}
+
+// InspectErr calls f with the error if the Result is Err and returns the Result unchanged.
+func (r Result[T]) InspectErr(f func(error)) Result[T] {
+	if r.err != nil {
+		f(r.err)
+	}
+	return r
+}
+
+// Filter turns an Ok into Err(err) if the value does not satisfy the predicate.
+// An Err is returned unchanged.
+func (r Result[T]) Filter(predicate func(T) bool, err error) Result[T] {
+	if r.err != nil || predicate(r.value) {
+		return r
+	}
+	return Err[T](err)
+}
+
 // Unwrap returns the value or panics if there's an error.
 func (r Result[T]) Unwrap() T {
 	if r.err != nil {
@@ -223,6 +409,22 @@ func (r Result[T]) Expect(msg string) T {
 	return r.value
 }
 
+// UnwrapErr returns the error or panics if the Result is Ok.
+func (r Result[T]) UnwrapErr() error {
+	if r.err == nil {
+		panic(fmt.Sprintf("called Result.UnwrapErr() on Ok value: %v", r.value))
+	}
+	return r.err
+}
+
+// ExpectErr returns the error or panics with a custom message if the Result is Ok.
+func (r Result[T]) ExpectErr(msg string) error {
+	if r.err == nil {
+		panic(fmt.Sprintf("%s: %v", msg, r.value))
+	}
+	return r.err
+}
+
 // IsOk returns true if the Result is Ok.
 func (r Result[T]) IsOk() bool {
 	return r.err == nil
@@ -243,6 +445,27 @@ func (r Result[T]) Value() (T, error) {
 	return r.value, r.err
 }
 
+// FromCore converts a core rust.Result into a Result.
+// A core Err holding a nil error still converts to an Err.
+func FromCore[T any](r rust.Result[T, error]) Result[T] {
+	if r.IsOk() {
+		return Ok(r.Unwrap())
+	}
+	err := r.UnwrapErr()
+	if err == nil {
+		err = stderrors.New("rust.Result Err with nil error")
+	}
+	return Err[T](err)
+}
+
+// ToCore converts a Result into a core rust.Result.
+func ToCore[T any](r Result[T]) rust.Result[T, error] {
+	if r.err != nil {
+		return rust.Err[T, error](r.err)
+	}
+	return rust.Ok[T, error](r.value)
+}
+
 // Try is a helper function that converts a function returning (T, error) to Result[T].
 func Try[T any](value T, err error) Result[T] {
 	if err != nil {
@@ -283,6 +506,42 @@ func Combine[T any](results ...Result[T]) Result[[]T] {
 	return Ok(values)
 }
 
+// CollectErrors splits Results into the values of the Ok ones and the errors
+// of the Err ones, keeping their order. Unlike Combine, it does not stop at
+// the first error.
+func CollectErrors[T any](results ...Result[T]) ([]T, []error) {
+	var values []T
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+		} else {
+			values = append(values, r.value)
+		}
+	}
+	return values, errs
+}
+
+// AllErrors joins the errors of all Err Results into one error, or returns nil if all are Ok.
+func AllErrors[T any](results ...Result[T]) error {
+	_, errs := CollectErrors(results...)
+	return Join(errs...)
+}
+
+// Retry calls f up to attempts times until it returns Ok, sleeping between
+// attempts with a delay that starts at backoff and doubles each time.
+// It returns the first Ok or the last Err. f is always called at least once.
+func Retry[T any](attempts int, backoff time.Duration, f func() Result[T]) Result[T] {
+	result := f()
+	delay := backoff
+	for i := 1; i < attempts && result.IsErr(); i++ {
+		time.Sleep(delay)
+		delay *= 2
+		result = f()
+	}
+	return result
+}
+
 // FirstError returns the first error from multiple Results.
 func FirstError[T any](results ...Result[T]) error {
 	for _, r := range results {
@@ -397,6 +656,14 @@ func (h *ErrorHandler) Log(logger func(string, ...interface{})) {
 	}
 }
 
+// LogAtLeast logs the error only if its severity is at least threshold.
+// The severity is taken from the first error in the chain that sets one.
+func (h *ErrorHandler) LogAtLeast(threshold Severity, logger func(string, ...interface{})) {
+	if h.err != nil && !h.skip && SeverityOf(h.err) >= threshold {
+		logger("Error: %v", h.err)
+	}
+}
+
 // ErrorChain represents a chain of errors for detailed error tracing.
 type ErrorChain []error
 
@@ -453,3 +720,110 @@ func Flatten(err error) ErrorChain {
 	}
 	return chain
 }
+
+// RootCause follows err's Unwrap chain and returns the deepest error, the
+// one with no cause. An error that wraps several errors, such as one built
+// by Join, has no single cause and is returned as is. RootCause(nil) is nil.
+func RootCause(err error) error {
+	for err != nil {
+		unwrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return err
+		}
+		cause := unwrapper.Unwrap()
+		if cause == nil {
+			return err
+		}
+		err = cause
+	}
+	return err
+}
+
+// Is reports whether any error in err's chain matches target.
+// The chain is followed through Unwrap, so a sentinel wrapped by Wrap or
+// Wrapf, or by a standard fmt.Errorf("%w"), still matches.
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+// As finds the first error in err's chain that matches target, and if one is
+// found, sets target to that error value and returns true. Like the standard
+// library, it panics if target is not a non-nil pointer to a type
+// implementing error or to an interface type.
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
+
+// CodeOf returns the code of the first error in err's chain that has one.
+func CodeOf(err error) (string, bool) {
+	found := find(err, func(e error) bool {
+		enhanced, ok := e.(*Error)
+		return ok && enhanced.Code != ""
+	})
+	if found == nil {
+		return "", false
+	}
+	return found.(*Error).Code, true
+}
+
+// find walks err's chain depth-first, following both Unwrap() error and
+// Unwrap() []error, and returns the first error for which match is true.
+func find(err error, match func(error) bool) error {
+	for err != nil {
+		if match(err) {
+			return err
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				if found := find(e, match); found != nil {
+					return found
+				}
+			}
+			return nil
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// SeverityOf returns the severity of the first error in err's chain that sets
+// one, or SeverityError if none does.
+func SeverityOf(err error) Severity {
+	found := find(err, func(e error) bool {
+		enhanced, ok := e.(*Error)
+		return ok && enhanced.Severity != 0
+	})
+	if found == nil {
+		return SeverityError
+	}
+	return found.(*Error).Severity
+}
+
+// Join returns an error that wraps the given errors, discarding nils.
+// It returns nil if every error is nil. The joined error's message is the
+// messages of the errors separated by newlines, and its Unwrap() []error
+// exposes them, so Is, As and CodeOf search each of them.
+func Join(errs ...error) error {
+	return stderrors.Join(errs...)
+}
+
+// ContextValue returns the value stored under key by the first error in
+// err's chain that carries that context key.
+func ContextValue(err error, key string) rust.Option[interface{}] {
+	found := find(err, func(e error) bool {
+		enhanced, ok := e.(*Error)
+		if !ok {
+			return false
+		}
+		_, ok = enhanced.Context[key]
+		return ok
+	})
+	if found == nil {
+		return rust.None[interface{}]()
+	}
+	return rust.Some(found.(*Error).Context[key])
+}
@@ -2,9 +2,13 @@
 package errors_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
+	rust "github.com/dongrv/rust-go"
 	"github.com/dongrv/rust-go/errors"
 )
 
@@ -60,6 +64,51 @@ func TestWithContext(t *testing.T) {
 	}
 }
 
+func TestGetContext(t *testing.T) {
+	err := errors.New("request failed").WithContext("status", 503)
+
+	// Test a present key
+	value := err.GetContext("status")
+	if !value.IsSome() || value.Unwrap() != 503 {
+		t.Errorf("Expected Some(503), got %v", value)
+	}
+
+	// Test a missing key
+	if err.GetContext("missing").IsSome() {
+		t.Error("Expected None for a missing key")
+	}
+
+	// Test a key holding nil is still present
+	if !err.WithContext("body", nil).GetContext("body").IsSome() {
+		t.Error("Expected Some for a key holding nil")
+	}
+}
+
+func TestContextValue(t *testing.T) {
+	inner := errors.New("query failed").WithContext("table", "users").WithContext("id", 7)
+	middle := errors.Wrap(inner, "load user").WithContext("id", 42)
+	outer := fmt.Errorf("handler: %w", middle)
+
+	// Test a key set only on the inner error
+	table := errors.ContextValue(outer, "table")
+	if !table.IsSome() || table.Unwrap() != "users" {
+		t.Errorf("Expected Some(users) from the inner error, got %v", table)
+	}
+
+	// Test the outermost error carrying the key wins
+	if id := errors.ContextValue(outer, "id"); id.Unwrap() != 42 {
+		t.Errorf("Expected the outer id 42, got %v", id)
+	}
+
+	// Test missing keys and nil errors
+	if errors.ContextValue(outer, "missing").IsSome() {
+		t.Error("Expected None for a key no error carries")
+	}
+	if errors.ContextValue(nil, "table").IsSome() {
+		t.Error("Expected None for a nil error")
+	}
+}
+
 func TestWithContextMap(t *testing.T) {
 	context := map[string]interface{}{
 		"key1": "value1",
@@ -75,6 +124,32 @@ func TestWithContextMap(t *testing.T) {
 	}
 }
 
+func TestWithCause(t *testing.T) {
+	inner := fmt.Errorf("disk full")
+	err := errors.New("save failed").WithContext("path", "/tmp/out").WithCause(inner)
+
+	// Test Unwrap returns the cause
+	if err.Unwrap() != inner {
+		t.Errorf("Expected Unwrap to return the cause, got %v", err.Unwrap())
+	}
+	if !errors.Is(err, inner) || errors.RootCause(err) != inner {
+		t.Error("Chain helpers should follow the explicit cause")
+	}
+
+	// Test the message and context are kept
+	if err.Error() != "save failed" {
+		t.Errorf("Expected the message to be unchanged, got %q", err.Error())
+	}
+	if err.Context["path"] != "/tmp/out" {
+		t.Error("WithCause should keep existing context")
+	}
+
+	// Test clearing the cause
+	if err.WithCause(nil).Unwrap() != nil {
+		t.Error("WithCause(nil) should clear the cause")
+	}
+}
+
 func TestResultOk(t *testing.T) {
 	result := errors.Ok(42)
 	if !result.IsOk() {
@@ -165,6 +240,50 @@ func TestResultAndThen(t *testing.T) {
 	}
 }
 
+func TestMapResultTo(t *testing.T) {
+	// Test mapping Ok to a different type
+	result := errors.MapResultTo(errors.Ok(42), func(x int) string { return "#" + strconv.Itoa(x) })
+	if result.Unwrap() != "#42" {
+		t.Errorf("Expected '#42', got %q", result.Unwrap())
+	}
+
+	// Test Err passes through without calling f
+	original := fmt.Errorf("failed")
+	called := false
+	mapped := errors.MapResultTo(errors.Err[int](original), func(x int) string { called = true; return "" })
+	if mapped.Error() != original || called {
+		t.Errorf("Expected the original error without calling f, got %v", mapped.Error())
+	}
+}
+
+func TestAndThenTo(t *testing.T) {
+	parse := func(s string) errors.Result[int] { return errors.Try(strconv.Atoi(s)) }
+	describe := func(n int) errors.Result[string] {
+		if n < 0 {
+			return errors.Err[string](fmt.Errorf("negative: %d", n))
+		}
+		return errors.Ok(strconv.Itoa(n) + " items")
+	}
+
+	// Test a pipeline changing types twice
+	result := errors.AndThenTo(errors.AndThenTo(errors.Ok("12"), parse), describe)
+	if result.Unwrap() != "12 items" {
+		t.Errorf("Expected '12 items', got %q", result.Unwrap())
+	}
+
+	// Test failures at each step
+	if result := errors.AndThenTo(errors.AndThenTo(errors.Ok("abc"), parse), describe); !result.IsErr() {
+		t.Error("Expected a parse failure")
+	}
+	if result := errors.AndThenTo(errors.AndThenTo(errors.Ok("-3"), parse), describe); result.Error().Error() != "negative: -3" {
+		t.Errorf("Expected 'negative: -3', got %v", result.Error())
+	}
+	original := fmt.Errorf("no input")
+	if result := errors.AndThenTo(errors.Err[string](original), parse); result.Error() != original {
+		t.Errorf("Expected the original error, got %v", result.Error())
+	}
+}
+
 func TestResultOrElse(t *testing.T) {
 	// Test OrElse on Err
 	err := fmt.Errorf("test error")
@@ -191,6 +310,51 @@ func TestResultOrElse(t *testing.T) {
 	}
 }
 
+func TestResultInspect(t *testing.T) {
+	// Test Inspect fires only on Ok
+	var seen []int
+	errors.Ok(7).Inspect(func(x int) { seen = append(seen, x) })
+	errors.Err[int](fmt.Errorf("failed")).Inspect(func(x int) { seen = append(seen, x) })
+	if len(seen) != 1 || seen[0] != 7 {
+		t.Errorf("Expected Inspect to see only 7, got %v", seen)
+	}
+
+	// Test InspectErr fires only on Err
+	var errs []error
+	failure := fmt.Errorf("failed")
+	result := errors.Err[int](failure).InspectErr(func(e error) { errs = append(errs, e) })
+	errors.Ok(7).InspectErr(func(e error) { errs = append(errs, e) })
+	if len(errs) != 1 || errs[0] != failure {
+		t.Errorf("Expected InspectErr to see only the failure, got %v", errs)
+	}
+	if result.Error() != failure {
+		t.Error("InspectErr should return the Result unchanged")
+	}
+}
+
+func TestResultFilter(t *testing.T) {
+	tooSmall := fmt.Errorf("value too small")
+	isLarge := func(x int) bool { return x >= 10 }
+
+	// Test Ok passing the predicate
+	if result := errors.Ok(42).Filter(isLarge, tooSmall); !result.IsOk() || result.Unwrap() != 42 {
+		t.Errorf("Expected Ok(42), got %v", result.Error())
+	}
+
+	// Test Ok failing the predicate
+	if result := errors.Ok(3).Filter(isLarge, tooSmall); result.Error() != tooSmall {
+		t.Errorf("Expected the filter error, got %v", result.Error())
+	}
+
+	// Test Err is unchanged and the predicate is not called
+	original := fmt.Errorf("parse failed")
+	called := false
+	result := errors.Err[int](original).Filter(func(int) bool { called = true; return false }, tooSmall)
+	if result.Error() != original || called {
+		t.Errorf("Expected the original error without calling the predicate, got %v", result.Error())
+	}
+}
+
 func TestResultUnwrapOr(t *testing.T) {
 	// Test UnwrapOr on Ok
 	result := errors.Ok(42)
@@ -247,6 +411,75 @@ func TestResultExpect(t *testing.T) {
 	result2.Expect("this should panic")
 }
 
+func TestResultUnwrapErr(t *testing.T) {
+	// Test UnwrapErr on Err
+	failure := fmt.Errorf("test error")
+	if errors.Err[int](failure).UnwrapErr() != failure {
+		t.Error("UnwrapErr should return the error")
+	}
+
+	// Test UnwrapErr on Ok
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Error("UnwrapErr should panic on Ok")
+		} else if r != "called Result.UnwrapErr() on Ok value: 42" {
+			t.Errorf("Unexpected panic message: %v", r)
+		}
+	}()
+	errors.Ok(42).UnwrapErr()
+}
+
+func TestResultExpectErr(t *testing.T) {
+	// Test ExpectErr on Err
+	failure := fmt.Errorf("test error")
+	if errors.Err[int](failure).ExpectErr("should not panic") != failure {
+		t.Error("ExpectErr should return the error")
+	}
+
+	// Test ExpectErr on Ok
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Error("ExpectErr should panic on Ok")
+		} else if r != "expected a failure: 42" {
+			t.Errorf("Unexpected panic message: %v", r)
+		}
+	}()
+	errors.Ok(42).ExpectErr("expected a failure")
+}
+
+func TestCoreConversion(t *testing.T) {
+	// Test Ok round trip
+	core := errors.ToCore(errors.Ok(42))
+	if !core.IsOk() || core.Unwrap() != 42 {
+		t.Errorf("Expected core Ok(42), got %v", core)
+	}
+	back := errors.FromCore(core)
+	if !back.IsOk() || back.Unwrap() != 42 {
+		t.Errorf("Expected Ok(42) after round trip, got %v", back)
+	}
+
+	// Test Err round trip
+	original := fmt.Errorf("connection refused")
+	core = errors.ToCore(errors.Err[int](original))
+	if !core.IsErr() || core.UnwrapErr() != original {
+		t.Errorf("Expected core Err with the original error, got %v", core)
+	}
+	back = errors.FromCore(core)
+	if !back.IsErr() || back.Error() != original {
+		t.Errorf("Expected Err with the original error after round trip, got %v", back.Error())
+	}
+
+	// Test converting from core constructors
+	if errors.FromCore(rust.Ok[string, error]("hi")).Unwrap() != "hi" {
+		t.Error("Expected FromCore to keep an Ok value")
+	}
+	if !errors.FromCore(rust.Err[string, error](nil)).IsErr() {
+		t.Error("Expected a core Err with a nil error to stay an Err")
+	}
+}
+
 func TestTry(t *testing.T) {
 	// Test Try with no error
 	result := errors.Try(42, nil)
@@ -348,6 +581,94 @@ func TestCombine(t *testing.T) {
 	}
 }
 
+func TestCollectErrors(t *testing.T) {
+	nameErr := fmt.Errorf("name is required")
+	ageErr := fmt.Errorf("age must be positive")
+	results := []errors.Result[int]{
+		errors.Ok(1),
+		errors.Err[int](nameErr),
+		errors.Ok(2),
+		errors.Err[int](ageErr),
+		errors.Ok(3),
+	}
+
+	// Test every value and error is collected in order
+	values, errs := errors.CollectErrors(results...)
+	if len(values) != 3 || values[0] != 1 || values[2] != 3 {
+		t.Errorf("Expected [1 2 3], got %v", values)
+	}
+	if len(errs) != 2 || errs[0] != nameErr || errs[1] != ageErr {
+		t.Errorf("Expected both errors in order, got %v", errs)
+	}
+
+	// Test AllErrors joins every failure
+	all := errors.AllErrors(results...)
+	if all == nil || all.Error() != "name is required\nage must be positive" {
+		t.Errorf("Expected both messages joined, got %v", all)
+	}
+	if !errors.Is(all, nameErr) || !errors.Is(all, ageErr) {
+		t.Error("Joined error should match each failure")
+	}
+
+	// Test all Ok
+	values, errs = errors.CollectErrors(errors.Ok(1), errors.Ok(2))
+	if len(values) != 2 || len(errs) != 0 {
+		t.Errorf("Expected 2 values and no errors, got %v, %v", values, errs)
+	}
+	if errors.AllErrors(errors.Ok(1)) != nil {
+		t.Error("AllErrors should be nil when every Result is Ok")
+	}
+}
+
+func TestRetry(t *testing.T) {
+	// Test success on the first try
+	calls := 0
+	result := errors.Retry(3, time.Millisecond, func() errors.Result[int] {
+		calls++
+		return errors.Ok(42)
+	})
+	if result.Unwrap() != 42 || calls != 1 {
+		t.Errorf("Expected Ok(42) after 1 call, got %v after %d calls", result, calls)
+	}
+
+	// Test success after failures with a growing delay
+	calls = 0
+	start := time.Now()
+	result = errors.Retry(5, 5*time.Millisecond, func() errors.Result[int] {
+		calls++
+		if calls < 3 {
+			return errors.Err[int](fmt.Errorf("attempt %d failed", calls))
+		}
+		return errors.Ok(calls)
+	})
+	if result.Unwrap() != 3 || calls != 3 {
+		t.Errorf("Expected Ok(3) after 3 calls, got %v after %d calls", result, calls)
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("Expected delays of 5ms then 10ms, took %v", elapsed)
+	}
+
+	// Test exhaustion returns the last error
+	calls = 0
+	result = errors.Retry(4, 0, func() errors.Result[int] {
+		calls++
+		return errors.Err[int](fmt.Errorf("attempt %d failed", calls))
+	})
+	if calls != 4 || result.Error().Error() != "attempt 4 failed" {
+		t.Errorf("Expected the last error after 4 calls, got %v after %d calls", result.Error(), calls)
+	}
+
+	// Test non-positive attempts still call once
+	calls = 0
+	errors.Retry(0, 0, func() errors.Result[int] {
+		calls++
+		return errors.Err[int](fmt.Errorf("failed"))
+	})
+	if calls != 1 {
+		t.Errorf("Expected 1 call with zero attempts, got %d", calls)
+	}
+}
+
 func TestFirstError(t *testing.T) {
 	// Test with no errors
 	results := []errors.Result[int]{
@@ -531,6 +852,32 @@ func TestFlatten(t *testing.T) {
 	}
 }
 
+func TestRootCause(t *testing.T) {
+	// Test a three-level Wrap chain
+	original := fmt.Errorf("connection reset")
+	chain := errors.Wrap(errors.Wrapf(errors.Wrap(original, "read"), "query %s", "users"), "load page")
+	if errors.RootCause(chain) != original {
+		t.Errorf("Expected the original error, got %v", errors.RootCause(chain))
+	}
+
+	// Test mixed standard and enhanced wrapping
+	mixed := fmt.Errorf("outer: %w", errors.Wrap(original, "inner"))
+	if errors.RootCause(mixed) != original {
+		t.Errorf("Expected the original error through mixed wrapping, got %v", errors.RootCause(mixed))
+	}
+
+	// Test an error without a cause is its own root
+	root := errors.New("standalone")
+	if errors.RootCause(root) != root {
+		t.Error("An error without a cause should be its own root")
+	}
+
+	// Test nil
+	if errors.RootCause(nil) != nil {
+		t.Error("RootCause of nil should be nil")
+	}
+}
+
 func TestErrorString(t *testing.T) {
 	err := errors.New("test error").
 		WithContext("key", "value").
@@ -549,6 +896,276 @@ func TestErrorString(t *testing.T) {
 	}
 }
 
+func TestIs(t *testing.T) {
+	sentinel := fmt.Errorf("not found")
+
+	// Test direct and wrapped matches
+	if !errors.Is(sentinel, sentinel) {
+		t.Error("Is should match the error itself")
+	}
+	wrapped := errors.Wrap(errors.Wrapf(sentinel, "load %s", "user"), "handle request")
+	if !errors.Is(wrapped, sentinel) {
+		t.Error("Is should match a sentinel through Wrap chains")
+	}
+
+	// Test mixing enhanced and standard wrapping
+	mixed := fmt.Errorf("outer: %w", errors.Wrap(sentinel, "inner"))
+	if !errors.Is(mixed, sentinel) {
+		t.Error("Is should follow standard wrapping into enhanced errors")
+	}
+
+	// Test enhanced sentinel
+	enhanced := errors.New("permission denied")
+	if !errors.Is(errors.Wrap(enhanced, "open file"), enhanced) {
+		t.Error("Is should match an enhanced sentinel")
+	}
+
+	// Test non-matches
+	if errors.Is(wrapped, fmt.Errorf("not found")) {
+		t.Error("Is should compare identity, not message")
+	}
+	if errors.Is(nil, sentinel) {
+		t.Error("Is should be false for a nil error")
+	}
+}
+
+type validationError struct {
+	Field string
+}
+
+func (e *validationError) Error() string {
+	return "invalid " + e.Field
+}
+
+func TestAs(t *testing.T) {
+	// Test extracting an enhanced error from standard wrapping
+	inner := errors.New("query failed").WithContext("table", "users")
+	chain := fmt.Errorf("service: %w", fmt.Errorf("repository: %w", inner))
+
+	var enhanced *errors.Error
+	if !errors.As(chain, &enhanced) {
+		t.Fatal("As should find the enhanced error in the chain")
+	}
+	if enhanced != inner || enhanced.Context["table"] != "users" {
+		t.Errorf("Expected the inner error with its context, got %v", enhanced)
+	}
+
+	// Test extracting a custom error from a multi-level Wrap chain
+	wrapped := errors.Wrap(errors.Wrap(&validationError{Field: "email"}, "validate"), "register")
+	var validation *validationError
+	if !errors.As(wrapped, &validation) {
+		t.Fatal("As should find the custom error through Wrap")
+	}
+	if validation.Field != "email" {
+		t.Errorf("Expected field 'email', got %q", validation.Field)
+	}
+
+	// Test the outermost enhanced error is found first
+	enhanced = nil
+	if !errors.As(wrapped, &enhanced) || enhanced != wrapped {
+		t.Error("As should return the first matching error in the chain")
+	}
+
+	// Test no match
+	if errors.As(fmt.Errorf("plain"), &validation) {
+		t.Error("As should be false when nothing in the chain matches")
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	// Test setting and reading a code
+	err := errors.New("user not found").WithCode("NOT_FOUND")
+	if err.Code != "NOT_FOUND" {
+		t.Errorf("Expected code 'NOT_FOUND', got %q", err.Code)
+	}
+
+	// Test CodeOf searching the chain
+	wrapped := fmt.Errorf("handler: %w", errors.Wrap(err, "lookup"))
+	code, ok := errors.CodeOf(wrapped)
+	if !ok || code != "NOT_FOUND" {
+		t.Errorf("Expected 'NOT_FOUND' from the chain, got %q, %v", code, ok)
+	}
+
+	// Test the outermost code wins
+	outer := errors.Wrap(err, "api").WithCode("BAD_REQUEST")
+	if code, _ := errors.CodeOf(outer); code != "BAD_REQUEST" {
+		t.Errorf("Expected the outermost code 'BAD_REQUEST', got %q", code)
+	}
+
+	// Test errors without a code
+	if _, ok := errors.CodeOf(errors.Wrap(fmt.Errorf("plain"), "context")); ok {
+		t.Error("CodeOf should report false when no error has a code")
+	}
+	if _, ok := errors.CodeOf(nil); ok {
+		t.Error("CodeOf should report false for nil")
+	}
+
+	// Test the code appears in String
+	if !contains(err.String(), "Code: NOT_FOUND") {
+		t.Errorf("String should contain the code, got %q", err.String())
+	}
+	if contains(errors.New("no code").String(), "Code:") {
+		t.Error("String should omit the code when it is not set")
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	// Test setting and reading severity
+	warn := errors.New("cache miss").WithSeverity(errors.SeverityWarn)
+	if warn.Severity != errors.SeverityWarn {
+		t.Errorf("Expected WARN, got %v", warn.Severity)
+	}
+	if errors.SeverityOf(errors.Wrap(warn, "lookup")) != errors.SeverityWarn {
+		t.Error("SeverityOf should find the severity through the chain")
+	}
+	if errors.SeverityOf(fmt.Errorf("plain")) != errors.SeverityError {
+		t.Error("Errors without a severity should default to ERROR")
+	}
+	if errors.SeverityFatal.String() != "FATAL" || errors.SeverityDebug.String() != "DEBUG" {
+		t.Error("Severity should format as its level name")
+	}
+	if !(errors.SeverityDebug < errors.SeverityInfo && errors.SeverityWarn < errors.SeverityFatal) {
+		t.Error("Severity levels should be ordered")
+	}
+
+	// Test severity-filtered logging
+	var logged []string
+	logger := func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}
+	errors.Handle(errors.New("verbose detail").WithSeverity(errors.SeverityDebug)).LogAtLeast(errors.SeverityWarn, logger)
+	errors.Handle(warn).LogAtLeast(errors.SeverityWarn, logger)
+	errors.Handle(fmt.Errorf("disk full")).LogAtLeast(errors.SeverityWarn, logger)
+	errors.Handle(nil).LogAtLeast(errors.SeverityDebug, logger)
+
+	if len(logged) != 2 || logged[0] != "Error: cache miss" || logged[1] != "Error: disk full" {
+		t.Errorf("Expected only WARN and ERROR errors to be logged, got %v", logged)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	// Test zero errors and only nils
+	if errors.Join() != nil {
+		t.Error("Join with no errors should be nil")
+	}
+	if errors.Join(nil, nil) != nil {
+		t.Error("Join with only nils should be nil")
+	}
+
+	// Test one error
+	single := fmt.Errorf("name is required")
+	joined := errors.Join(nil, single)
+	if joined == nil || joined.Error() != "name is required" {
+		t.Errorf("Expected the single message, got %v", joined)
+	}
+
+	// Test several errors including nils
+	emailErr := errors.New("email is invalid").WithCode("INVALID_EMAIL")
+	joined = errors.Join(single, nil, emailErr, fmt.Errorf("age must be positive"))
+	expected := "name is required\nemail is invalid\nage must be positive"
+	if joined.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, joined.Error())
+	}
+	unwrapped := joined.(interface{ Unwrap() []error }).Unwrap()
+	if len(unwrapped) != 3 {
+		t.Errorf("Expected 3 wrapped errors, got %d", len(unwrapped))
+	}
+
+	// Test chain helpers see every joined error
+	if !errors.Is(joined, single) || !errors.Is(joined, emailErr) {
+		t.Error("Is should match each joined error")
+	}
+	if code, ok := errors.CodeOf(joined); !ok || code != "INVALID_EMAIL" {
+		t.Errorf("Expected CodeOf to find 'INVALID_EMAIL', got %q, %v", code, ok)
+	}
+}
+
+func TestErrorFormat(t *testing.T) {
+	err := errors.Wrap(fmt.Errorf("timeout"), "fetch profile").
+		WithCode("UPSTREAM").
+		WithContext("host", "api.example.com")
+
+	// Test %v and %s print only the message
+	if got := fmt.Sprintf("%v", err); got != "fetch profile: timeout" {
+		t.Errorf("Expected the message for %%v, got %q", got)
+	}
+	if got := fmt.Sprintf("%s", err); got != "fetch profile: timeout" {
+		t.Errorf("Expected the message for %%s, got %q", got)
+	}
+	if got := fmt.Sprintf("%q", err); got != `"fetch profile: timeout"` {
+		t.Errorf("Expected the quoted message for %%q, got %q", got)
+	}
+
+	// Test %+v includes code, context and stack trace
+	detailed := fmt.Sprintf("%+v", err)
+	for _, part := range []string{"fetch profile: timeout", "Code: UPSTREAM", "host: api.example.com", "Stack trace:", "TestErrorFormat"} {
+		if !contains(detailed, part) {
+			t.Errorf("Expected %%+v output to contain %q, got:\n%s", part, detailed)
+		}
+	}
+
+	// Test formatting through a standard wrapper uses the message
+	if got := fmt.Sprintf("%v", fmt.Errorf("outer: %w", err)); got != "outer: fetch profile: timeout" {
+		t.Errorf("Expected the wrapped message, got %q", got)
+	}
+}
+
+func TestErrorJSON(t *testing.T) {
+	// Test an error without context, code or cause
+	data, err := json.Marshal(errors.New("not found"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"message":"not found"}` {
+		t.Errorf("Expected only the message, got %s", data)
+	}
+
+	// Test an error with code, context and cause
+	wrapped := errors.Wrap(fmt.Errorf("connection refused"), "save order").
+		WithCode("DB_UNAVAILABLE").
+		WithContext("order_id", 17)
+	data, err = json.Marshal(wrapped)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded["message"] != "save order: connection refused" || decoded["code"] != "DB_UNAVAILABLE" ||
+		decoded["cause"] != "connection refused" {
+		t.Errorf("Unexpected JSON fields: %s", data)
+	}
+	context, ok := decoded["context"].(map[string]interface{})
+	if !ok || context["order_id"] != float64(17) {
+		t.Errorf("Expected context with order_id, got %s", data)
+	}
+	if _, ok := decoded["stack"]; ok {
+		t.Error("Stack should be omitted by default")
+	}
+
+	// Test including the stack
+	data, err = wrapped.MarshalJSONWith(errors.JSONOptions{IncludeStack: true})
+	if err != nil {
+		t.Fatalf("MarshalJSONWith failed: %v", err)
+	}
+	decoded = nil
+	json.Unmarshal(data, &decoded)
+	stack, ok := decoded["stack"].([]interface{})
+	if !ok || !contains(fmt.Sprint(stack...), "TestErrorJSON") {
+		t.Errorf("Expected stack frames including the test, got %v", decoded["stack"])
+	}
+
+	// Test embedding in a response struct
+	response := struct {
+		Error *errors.Error `json:"error"`
+	}{Error: errors.New("bad input").WithCode("INVALID")}
+	data, _ = json.Marshal(response)
+	if string(data) != `{"error":{"message":"bad input","code":"INVALID"}}` {
+		t.Errorf("Unexpected embedded JSON: %s", data)
+	}
+}
+
 func contains(s, substr string) bool {
 	for i := 0; i <= len(s)-len(substr); i++ {
 		if s[i:i+len(substr)] == substr {
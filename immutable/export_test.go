@@ -0,0 +1,7 @@
+package immutable
+
+// OrderedMapBackingLength exposes the length of an ordered map's entry
+// vector, including tombstones, to tests.
+func OrderedMapBackingLength[K comparable, V any](m *OrderedMap[K, V]) int {
+	return m.entries.Length()
+}
@@ -3,8 +3,15 @@
 package immutable
 
 import (
+	"encoding/binary"
 	"fmt"
+	"hash/maphash"
+	"math"
+	"math/bits"
+	"reflect"
 	"strings"
+
+	rust "github.com/dongrv/rust-go"
 )
 
 // List is a persistent immutable singly-linked list.
@@ -77,6 +84,66 @@ func (l *List[T]) Tail() *List[T] {
 	}
 }
 
+// Get returns the element at the given index, or None if index is out of bounds.
+// The list is walked from the head, so this is O(n).
+func (l *List[T]) Get(index int) rust.Option[T] {
+	if index < 0 || index >= l.size {
+		return rust.None[T]()
+	}
+	node := l.head
+	for i := 0; i < index; i++ {
+		node = node.next
+	}
+	return rust.Some(node.value)
+}
+
+// Take returns a new list of the first n elements.
+// Returns an empty list if n <= 0 and the list itself if n >= size.
+func (l *List[T]) Take(n int) *List[T] {
+	if n <= 0 {
+		return EmptyList[T]()
+	}
+	if n >= l.size {
+		return l
+	}
+
+	values := make([]T, 0, n)
+	for node := l.head; len(values) < n; node = node.next {
+		values = append(values, node.value)
+	}
+	return ListOf(values...)
+}
+
+// Drop returns the list without its first n elements, sharing the remaining nodes.
+// Returns the list itself if n <= 0 and an empty list if n >= size.
+func (l *List[T]) Drop(n int) *List[T] {
+	if n <= 0 {
+		return l
+	}
+	if n >= l.size {
+		return EmptyList[T]()
+	}
+
+	node := l.head
+	for i := 0; i < n; i++ {
+		node = node.next
+	}
+	return &List[T]{
+		head: node,
+		size: l.size - n,
+	}
+}
+
+// Find returns the first element that satisfies the predicate.
+func (l *List[T]) Find(predicate func(T) bool) rust.Option[T] {
+	for node := l.head; node != nil; node = node.next {
+		if predicate(node.value) {
+			return rust.Some(node.value)
+		}
+	}
+	return rust.None[T]()
+}
+
 // IsEmpty returns true if the list is empty.
 func (l *List[T]) IsEmpty() bool {
 	return l.head == nil
@@ -183,6 +250,24 @@ func (l *List[T]) ForEach(f func(T)) {
 	}
 }
 
+// Iter returns an iterator over the elements of the list.
+func (l *List[T]) Iter() rust.Iterator[T] {
+	return &listIterator[T]{node: l.head}
+}
+
+type listIterator[T any] struct {
+	node *listNode[T]
+}
+
+func (it *listIterator[T]) Next() rust.Option[T] {
+	if it.node == nil {
+		return rust.None[T]()
+	}
+	value := it.node.value
+	it.node = it.node.next
+	return rust.Some(value)
+}
+
 // ToSlice converts the list to a slice.
 func (l *List[T]) ToSlice() []T {
 	if l.IsEmpty() {
@@ -214,6 +299,58 @@ func (l *List[T]) String() string {
 	return sb.String()
 }
 
+// MapListTo applies a type-changing function to each element and returns a new list.
+func MapListTo[T any, U any](l *List[T], f func(T) U) *List[U] {
+	values := make([]U, 0, l.size)
+	for node := l.head; node != nil; node = node.next {
+		values = append(values, f(node.value))
+	}
+	return ListOf(values...)
+}
+
+// FlatMapList maps each element to a list and concatenates the results.
+func FlatMapList[T any, U any](l *List[T], f func(T) *List[U]) *List[U] {
+	var values []U
+	for node := l.head; node != nil; node = node.next {
+		values = append(values, f(node.value).ToSlice()...)
+	}
+	return ListOf(values...)
+}
+
+// ContainsList returns true if the list contains the value.
+func ContainsList[T comparable](l *List[T], value T) bool {
+	return IndexOfList(l, value).IsSome()
+}
+
+// IndexOfList returns the index of the first element equal to value.
+func IndexOfList[T comparable](l *List[T], value T) rust.Option[int] {
+	i := 0
+	for node := l.head; node != nil; node = node.next {
+		if node.value == value {
+			return rust.Some(i)
+		}
+		i++
+	}
+	return rust.None[int]()
+}
+
+// EqualList returns true if both lists have the same size and equal elements in the same order.
+func EqualList[T comparable](a, b *List[T]) bool {
+	if a.size != b.size {
+		return false
+	}
+	for na, nb := a.head, b.head; na != nil; na, nb = na.next, nb.next {
+		if na == nb {
+			// Shared structure from here on
+			return true
+		}
+		if na.value != nb.value {
+			return false
+		}
+	}
+	return true
+}
+
 // Vector is a persistent immutable vector (array-like structure).
 // It uses a balanced tree structure for efficient updates.
 type Vector[T any] struct {
@@ -244,11 +381,11 @@ func EmptyVector[T any]() *Vector[T] {
 
 // VectorOf creates a vector from the given values.
 func VectorOf[T any](values ...T) *Vector[T] {
-	v := EmptyVector[T]()
+	t := NewTransientVector[T]()
 	for _, value := range values {
-		v = v.Append(value)
+		t.Push(value)
 	}
-	return v
+	return t.Persistent()
 }
 
 // Append adds an element to the end of the vector.
@@ -268,40 +405,140 @@ func (v *Vector[T]) Append(value T) *Vector[T] {
 	}
 
 	// Tail is full, need to push it into the tree
-	newRoot := v.pushTail(v.shift, v.root, v.tail)
-	newTail := []T{value}
+	tailNode := &vectorNode[T]{children: make([]interface{}, len(v.tail))}
+	for i, elem := range v.tail {
+		tailNode.children[i] = elem
+	}
+
+	var newRoot *vectorNode[T]
+	newShift := v.shift
+	if (v.length >> vectorShift) > (1 << v.shift) {
+		// Root is full, grow the tree by one level
+		newRoot = &vectorNode[T]{
+			children: []interface{}{v.root, newPath(v.shift, tailNode)},
+		}
+		newShift += vectorShift
+	} else {
+		newRoot = v.pushTail(v.shift, v.root, tailNode)
+	}
+
+	newTail := make([]T, 1, vectorNodeSize)
+	newTail[0] = value
 	return &Vector[T]{
 		root:   newRoot,
 		tail:   newTail,
 		length: v.length + 1,
-		shift:  v.shift,
+		shift:  newShift,
 	}
 }
 
-func (v *Vector[T]) pushTail(level uint, node *vectorNode[T], tail []T) *vectorNode[T] {
-	if node == nil {
-		// Create new root node
-		return &vectorNode[T]{
-			children: []interface{}{tail},
-		}
+func (v *Vector[T]) pushTail(level uint, node *vectorNode[T], tailNode *vectorNode[T]) *vectorNode[T] {
+	subIdx := ((v.length - 1) >> level) & (vectorNodeSize - 1)
+
+	var children []interface{}
+	if node != nil {
+		children = make([]interface{}, len(node.children), subIdx+1)
+		copy(children, node.children)
+	}
+	if subIdx >= len(children) {
+		children = append(children, make([]interface{}, subIdx+1-len(children))...)
+	}
+
+	if level == vectorShift {
+		// Parent of leaves
+		children[subIdx] = tailNode
+	} else if child, ok := children[subIdx].(*vectorNode[T]); ok && child != nil {
+		children[subIdx] = v.pushTail(level-vectorShift, child, tailNode)
+	} else {
+		children[subIdx] = newPath(level-vectorShift, tailNode)
+	}
+
+	return &vectorNode[T]{
+		children: children,
 	}
+}
 
+// newPath wraps a leaf node in empty parents down from the given level.
+func newPath[T any](level uint, node *vectorNode[T]) *vectorNode[T] {
 	if level == 0 {
-		// Leaf node
-		children := make([]interface{}, len(node.children)+1)
-		copy(children, node.children)
-		children[len(node.children)] = tail
+		return node
+	}
+	return &vectorNode[T]{
+		children: []interface{}{newPath(level-vectorShift, node)},
+	}
+}
+
+// Pop removes the last element of the vector.
+// Returns a new vector without the element and the removed value, or the vector itself and None if empty.
+func (v *Vector[T]) Pop() (*Vector[T], rust.Option[T]) {
+	if v.length == 0 {
+		return v, rust.None[T]()
+	}
+	last := v.tail[len(v.tail)-1]
+	if v.length == 1 {
+		return EmptyVector[T](), rust.Some(last)
+	}
+
+	if len(v.tail) > 1 {
+		// Shrink the tail
+		newTail := make([]T, len(v.tail)-1, vectorNodeSize)
+		copy(newTail, v.tail)
+		return &Vector[T]{
+			root:   v.root,
+			tail:   newTail,
+			length: v.length - 1,
+			shift:  v.shift,
+		}, rust.Some(last)
+	}
+
+	// Tail becomes empty, pull the last leaf out of the tree
+	leaf := v.leafFor(v.length - 2)
+	newTail := make([]T, len(leaf.children), vectorNodeSize)
+	for i, elem := range leaf.children {
+		newTail[i] = elem.(T)
+	}
+
+	newRoot := v.popTail(v.shift, v.root)
+	newShift := v.shift
+	if newRoot != nil && newShift > vectorShift && len(newRoot.children) == 1 {
+		// Root has a single child, drop a level
+		newRoot = newRoot.children[0].(*vectorNode[T])
+		newShift -= vectorShift
+	}
+
+	return &Vector[T]{
+		root:   newRoot,
+		tail:   newTail,
+		length: v.length - 1,
+		shift:  newShift,
+	}, rust.Some(last)
+}
+
+func (v *Vector[T]) popTail(level uint, node *vectorNode[T]) *vectorNode[T] {
+	subIdx := ((v.length - 2) >> level) & (vectorNodeSize - 1)
+
+	if level > vectorShift {
+		newChild := v.popTail(level-vectorShift, node.children[subIdx].(*vectorNode[T]))
+		if newChild == nil && subIdx == 0 {
+			return nil
+		}
+		children := make([]interface{}, subIdx+1)
+		copy(children, node.children[:subIdx])
+		if newChild == nil {
+			children = children[:subIdx]
+		} else {
+			children[subIdx] = newChild
+		}
 		return &vectorNode[T]{
 			children: children,
 		}
 	}
 
-	// Internal node
-	subIdx := ((v.length - 1) >> level) & (vectorNodeSize - 1)
-	child := v.pushTail(level-vectorShift, node.children[subIdx].(*vectorNode[T]), tail)
-	children := make([]interface{}, len(node.children))
-	copy(children, node.children)
-	children[subIdx] = child
+	if subIdx == 0 {
+		return nil
+	}
+	children := make([]interface{}, subIdx)
+	copy(children, node.children[:subIdx])
 	return &vectorNode[T]{
 		children: children,
 	}
@@ -320,13 +557,18 @@ func (v *Vector[T]) Get(index int) T {
 	}
 
 	// In tree
+	subIdx := index & (vectorNodeSize - 1)
+	return v.leafFor(index).children[subIdx].(T)
+}
+
+// leafFor returns the leaf node holding the element at an index stored in the tree.
+func (v *Vector[T]) leafFor(index int) *vectorNode[T] {
 	node := v.root
 	for level := v.shift; level > 0; level -= vectorShift {
 		subIdx := (index >> level) & (vectorNodeSize - 1)
 		node = node.children[subIdx].(*vectorNode[T])
 	}
-	subIdx := index & (vectorNodeSize - 1)
-	return node.children[subIdx].(T)
+	return node
 }
 
 // Set replaces the element at the given index.
@@ -359,6 +601,15 @@ func (v *Vector[T]) Set(index int, value T) *Vector[T] {
 	}
 }
 
+// Update applies f to the element at the given index.
+// Returns a new vector with the element replaced. Panics if index is out of bounds.
+func (v *Vector[T]) Update(index int, f func(T) T) *Vector[T] {
+	if index < 0 || index >= v.length {
+		panic(fmt.Sprintf("Vector.Update: index %d out of bounds [0, %d)", index, v.length))
+	}
+	return v.Set(index, f(v.Get(index)))
+}
+
 func (v *Vector[T]) setNode(level uint, node *vectorNode[T], index int, value T) *vectorNode[T] {
 	if level == 0 {
 		// Leaf node
@@ -381,6 +632,23 @@ func (v *Vector[T]) setNode(level uint, node *vectorNode[T], index int, value T)
 	}
 }
 
+// Slice returns a new vector containing the elements in [start, end).
+// Panics if the range is invalid. The result is rebuilt element by element, so this is O(end-start).
+func (v *Vector[T]) Slice(start, end int) *Vector[T] {
+	if start < 0 || end > v.length || start > end {
+		panic(fmt.Sprintf("Vector.Slice: invalid range [%d, %d) for length %d", start, end, v.length))
+	}
+	if start == 0 && end == v.length {
+		return v
+	}
+
+	result := EmptyVector[T]()
+	for i := start; i < end; i++ {
+		result = result.Append(v.Get(i))
+	}
+	return result
+}
+
 // Length returns the number of elements in the vector.
 func (v *Vector[T]) Length() int {
 	return v.length
@@ -427,6 +695,30 @@ func (v *Vector[T]) ForEach(f func(T)) {
 	}
 }
 
+// Iter returns an iterator over the elements of the vector.
+func (v *Vector[T]) Iter() rust.Iterator[T] {
+	return &vectorIterator[T]{vector: v, index: 0}
+}
+
+type vectorIterator[T any] struct {
+	vector *Vector[T]
+	index  int
+}
+
+func (it *vectorIterator[T]) Next() rust.Option[T] {
+	if it.index >= it.vector.length {
+		return rust.None[T]()
+	}
+	value := it.vector.Get(it.index)
+	it.index++
+	return rust.Some(value)
+}
+
+// Find returns the first element that satisfies the predicate.
+func (v *Vector[T]) Find(predicate func(T) bool) rust.Option[T] {
+	return rust.Find(v.Iter(), predicate)
+}
+
 // ToSlice converts the vector to a slice.
 func (v *Vector[T]) ToSlice() []T {
 	result := make([]T, v.length)
@@ -450,16 +742,126 @@ func (v *Vector[T]) String() string {
 	return sb.String()
 }
 
+// ContainsVector returns true if the vector contains the value.
+func ContainsVector[T comparable](v *Vector[T], value T) bool {
+	return IndexOfVector(v, value).IsSome()
+}
+
+// IndexOfVector returns the index of the first element equal to value.
+func IndexOfVector[T comparable](v *Vector[T], value T) rust.Option[int] {
+	return rust.Position(v.Iter(), func(x T) bool { return x == value })
+}
+
+// TransientVector is a mutable builder for constructing a Vector in bulk.
+// Pushing elements does not create intermediate vectors; the tree is built once by Persistent.
+// A TransientVector is not safe for concurrent use.
+type TransientVector[T any] struct {
+	values []T
+}
+
+// NewTransientVector creates an empty transient vector.
+func NewTransientVector[T any]() *TransientVector[T] {
+	return &TransientVector[T]{}
+}
+
+// Push adds an element to the end of the transient vector.
+func (t *TransientVector[T]) Push(value T) *TransientVector[T] {
+	t.values = append(t.values, value)
+	return t
+}
+
+// Length returns the number of elements pushed so far.
+func (t *TransientVector[T]) Length() int {
+	return len(t.values)
+}
+
+// Persistent builds an immutable vector from the pushed elements.
+// The transient vector may keep being used; later pushes do not affect the returned vector.
+func (t *TransientVector[T]) Persistent() *Vector[T] {
+	n := len(t.values)
+	if n == 0 {
+		return EmptyVector[T]()
+	}
+
+	// The last 1 to 32 elements live in the tail, the rest fill leaves of the tree
+	tailStart := ((n - 1) >> vectorShift) << vectorShift
+	tail := make([]T, n-tailStart, vectorNodeSize)
+	copy(tail, t.values[tailStart:])
+
+	var nodes []*vectorNode[T]
+	for i := 0; i < tailStart; i += vectorNodeSize {
+		leaf := &vectorNode[T]{children: make([]interface{}, vectorNodeSize)}
+		for j := range leaf.children {
+			leaf.children[j] = t.values[i+j]
+		}
+		nodes = append(nodes, leaf)
+	}
+
+	// Group nodes into parents until a single root remains
+	var root *vectorNode[T]
+	shift := uint(vectorShift)
+	for len(nodes) > 0 {
+		var parents []*vectorNode[T]
+		for i := 0; i < len(nodes); i += vectorNodeSize {
+			end := i + vectorNodeSize
+			if end > len(nodes) {
+				end = len(nodes)
+			}
+			parent := &vectorNode[T]{children: make([]interface{}, end-i)}
+			for j, child := range nodes[i:end] {
+				parent.children[j] = child
+			}
+			parents = append(parents, parent)
+		}
+		if len(parents) == 1 {
+			root = parents[0]
+			break
+		}
+		nodes = parents
+		shift += vectorShift
+	}
+
+	return &Vector[T]{
+		root:   root,
+		tail:   tail,
+		length: n,
+		shift:  shift,
+	}
+}
+
 // Map is a persistent immutable hash map.
-// This is a simplified implementation using a slice of key-value pairs.
-// For production use, consider a more efficient data structure.
+// It is implemented as a hash array mapped trie (HAMT), so Get, Set and Delete
+// are O(log32 n) and updates share all untouched nodes with the original map.
+// Iteration order follows key hashes and is not insertion order.
 type Map[K comparable, V any] struct {
+	root *hamtNode[K, V]
+	size int
+}
+
+// hamtNode is a trie node holding up to 32 entries indexed by 5 bits of the key hash.
+type hamtNode[K comparable, V any] struct {
+	bitmap  uint32
+	entries []hamtEntry[K, V]
+}
+
+// hamtEntry is either a child node or a leaf of pairs sharing one full hash.
+// A leaf holds more than one pair only when different keys collide on the full hash.
+type hamtEntry[K comparable, V any] struct {
+	node  *hamtNode[K, V]
+	hash  uint64
 	pairs []Pair[K, V]
 }
 
+const (
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+)
+
+var hamtSeed = maphash.MakeSeed()
+
 // EmptyMap creates an empty map.
 func EmptyMap[K comparable, V any]() *Map[K, V] {
-	return &Map[K, V]{pairs: []Pair[K, V]{}}
+	return &Map[K, V]{root: nil, size: 0}
 }
 
 // MapOf creates a map from key-value pairs.
@@ -485,65 +887,337 @@ func PairOf[K comparable, V any](key K, value V) Pair[K, V] {
 // Set adds or updates a key-value pair.
 // Returns a new map with the pair added/updated.
 func (m *Map[K, V]) Set(key K, value V) *Map[K, V] {
-	// Create a new slice
-	newPairs := make([]Pair[K, V], 0, len(m.pairs)+1)
-	found := false
-
-	// Copy existing pairs, updating if key exists
-	for _, pair := range m.pairs {
-		if pair.Key == key {
-			// Update existing key
-			newPairs = append(newPairs, Pair[K, V]{Key: key, Value: value})
-			found = true
-		} else {
-			newPairs = append(newPairs, pair)
-		}
-	}
-
-	// Add new key if not found
-	if !found {
-		newPairs = append(newPairs, Pair[K, V]{Key: key, Value: value})
+	newRoot, added := m.root.set(hashKey(key), 0, Pair[K, V]{Key: key, Value: value})
+	size := m.size
+	if added {
+		size++
 	}
-
-	return &Map[K, V]{pairs: newPairs}
+	return &Map[K, V]{root: newRoot, size: size}
 }
 
 // Get returns the value for the given key.
 // Returns false as second return value if key not found.
 func (m *Map[K, V]) Get(key K) (V, bool) {
-	for _, pair := range m.pairs {
-		if pair.Key == key {
-			return pair.Value, true
+	hash := hashKey(key)
+	node := m.root
+	for shift := uint(0); node != nil; shift += hamtBits {
+		bit := uint32(1) << ((hash >> shift) & hamtMask)
+		if node.bitmap&bit == 0 {
+			break
+		}
+		entry := node.entries[bits.OnesCount32(node.bitmap&(bit-1))]
+		if entry.node != nil {
+			node = entry.node
+			continue
+		}
+		if entry.hash == hash {
+			for _, pair := range entry.pairs {
+				if pair.Key == key {
+					return pair.Value, true
+				}
+			}
 		}
+		break
 	}
 	var zero V
 	return zero, false
 }
 
+// GetOrDefault returns the value for the given key, or def if the key is not found.
+func (m *Map[K, V]) GetOrDefault(key K, def V) V {
+	if value, found := m.Get(key); found {
+		return value
+	}
+	return def
+}
+
+// GetOption returns the value for the given key as an Option.
+func (m *Map[K, V]) GetOption(key K) rust.Option[V] {
+	if value, found := m.Get(key); found {
+		return rust.Some(value)
+	}
+	return rust.None[V]()
+}
+
 // Delete removes a key from the map.
 // Returns a new map without the key.
 func (m *Map[K, V]) Delete(key K) *Map[K, V] {
-	// Create a new slice
-	newPairs := make([]Pair[K, V], 0, len(m.pairs))
+	newRoot, removed := m.root.delete(hashKey(key), 0, key)
+	if !removed {
+		return m
+	}
+	return &Map[K, V]{root: newRoot, size: m.size - 1}
+}
 
-	// Copy all pairs except the one to delete
-	for _, pair := range m.pairs {
-		if pair.Key != key {
-			newPairs = append(newPairs, pair)
+// Update applies f to the current value of key (None if absent).
+// If f returns Some, the key is set to that value; if it returns None, the key is removed.
+func (m *Map[K, V]) Update(key K, f func(rust.Option[V]) rust.Option[V]) *Map[K, V] {
+	result := f(m.GetOption(key))
+	if result.IsNone() {
+		return m.Delete(key)
+	}
+	return m.Set(key, result.Unwrap())
+}
+
+// set returns a copy of the node with the pair inserted and whether the key is new.
+// A nil node is treated as empty.
+func (n *hamtNode[K, V]) set(hash uint64, shift uint, pair Pair[K, V]) (*hamtNode[K, V], bool) {
+	bit := uint32(1) << ((hash >> shift) & hamtMask)
+	if n == nil {
+		return &hamtNode[K, V]{
+			bitmap:  bit,
+			entries: []hamtEntry[K, V]{{hash: hash, pairs: []Pair[K, V]{pair}}},
+		}, true
+	}
+
+	idx := bits.OnesCount32(n.bitmap & (bit - 1))
+	if n.bitmap&bit == 0 {
+		// Empty slot, insert a new leaf
+		entries := make([]hamtEntry[K, V], len(n.entries)+1)
+		copy(entries, n.entries[:idx])
+		entries[idx] = hamtEntry[K, V]{hash: hash, pairs: []Pair[K, V]{pair}}
+		copy(entries[idx+1:], n.entries[idx:])
+		return &hamtNode[K, V]{bitmap: n.bitmap | bit, entries: entries}, true
+	}
+
+	entry := n.entries[idx]
+	var replacement hamtEntry[K, V]
+	added := false
+	switch {
+	case entry.node != nil:
+		// Descend into the child node
+		child, childAdded := entry.node.set(hash, shift+hamtBits, pair)
+		replacement = hamtEntry[K, V]{node: child}
+		added = childAdded
+	case entry.hash == hash:
+		// Same full hash, update the key or add a collision
+		pairs := make([]Pair[K, V], len(entry.pairs), len(entry.pairs)+1)
+		copy(pairs, entry.pairs)
+		found := false
+		for i := range pairs {
+			if pairs[i].Key == pair.Key {
+				pairs[i] = pair
+				found = true
+				break
+			}
+		}
+		if !found {
+			pairs = append(pairs, pair)
+			added = true
+		}
+		replacement = hamtEntry[K, V]{hash: hash, pairs: pairs}
+	default:
+		// Different hash in the same slot, push both leaves down a level
+		leaf := hamtEntry[K, V]{hash: hash, pairs: []Pair[K, V]{pair}}
+		replacement = hamtEntry[K, V]{node: mergeLeaves(shift+hamtBits, entry, leaf)}
+		added = true
+	}
+
+	entries := make([]hamtEntry[K, V], len(n.entries))
+	copy(entries, n.entries)
+	entries[idx] = replacement
+	return &hamtNode[K, V]{bitmap: n.bitmap, entries: entries}, added
+}
+
+// mergeLeaves builds the smallest subtree that separates two leaves with different hashes.
+func mergeLeaves[K comparable, V any](shift uint, a, b hamtEntry[K, V]) *hamtNode[K, V] {
+	idxA := (a.hash >> shift) & hamtMask
+	idxB := (b.hash >> shift) & hamtMask
+	if idxA == idxB {
+		return &hamtNode[K, V]{
+			bitmap:  uint32(1) << idxA,
+			entries: []hamtEntry[K, V]{{node: mergeLeaves(shift+hamtBits, a, b)}},
+		}
+	}
+	if idxA > idxB {
+		a, b = b, a
+	}
+	return &hamtNode[K, V]{
+		bitmap:  uint32(1)<<idxA | uint32(1)<<idxB,
+		entries: []hamtEntry[K, V]{a, b},
+	}
+}
+
+// delete returns a copy of the node without the key and whether the key was present.
+// Returns a nil node when the last entry is removed.
+func (n *hamtNode[K, V]) delete(hash uint64, shift uint, key K) (*hamtNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	bit := uint32(1) << ((hash >> shift) & hamtMask)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	idx := bits.OnesCount32(n.bitmap & (bit - 1))
+	entry := n.entries[idx]
+	var replacement hamtEntry[K, V]
+	keep := true
+	if entry.node != nil {
+		child, removed := entry.node.delete(hash, shift+hamtBits, key)
+		if !removed {
+			return n, false
+		}
+		switch {
+		case child == nil:
+			keep = false
+		case len(child.entries) == 1 && child.entries[0].node == nil:
+			// Collapse a child holding a single leaf into this node
+			replacement = child.entries[0]
+		default:
+			replacement = hamtEntry[K, V]{node: child}
+		}
+	} else {
+		if entry.hash != hash {
+			return n, false
+		}
+		pos := -1
+		for i, pair := range entry.pairs {
+			if pair.Key == key {
+				pos = i
+				break
+			}
+		}
+		if pos < 0 {
+			return n, false
+		}
+		if len(entry.pairs) == 1 {
+			keep = false
+		} else {
+			pairs := make([]Pair[K, V], 0, len(entry.pairs)-1)
+			pairs = append(pairs, entry.pairs[:pos]...)
+			pairs = append(pairs, entry.pairs[pos+1:]...)
+			replacement = hamtEntry[K, V]{hash: hash, pairs: pairs}
+		}
+	}
+
+	if keep {
+		entries := make([]hamtEntry[K, V], len(n.entries))
+		copy(entries, n.entries)
+		entries[idx] = replacement
+		return &hamtNode[K, V]{bitmap: n.bitmap, entries: entries}, true
+	}
+	if len(n.entries) == 1 {
+		return nil, true
+	}
+	entries := make([]hamtEntry[K, V], 0, len(n.entries)-1)
+	entries = append(entries, n.entries[:idx]...)
+	entries = append(entries, n.entries[idx+1:]...)
+	return &hamtNode[K, V]{bitmap: n.bitmap &^ bit, entries: entries}, true
+}
+
+// forEach visits every pair in the subtree, stopping early if f returns false.
+func (n *hamtNode[K, V]) forEach(f func(Pair[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, entry := range n.entries {
+		if entry.node != nil {
+			if !entry.node.forEach(f) {
+				return false
+			}
+			continue
+		}
+		for _, pair := range entry.pairs {
+			if !f(pair) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hashKey hashes any comparable key so that equal keys always produce equal hashes.
+func hashKey[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(hamtSeed, k)
+	case int:
+		return mix64(uint64(k))
+	case int64:
+		return mix64(uint64(k))
+	case uint64:
+		return mix64(k)
+	}
+	var h maphash.Hash
+	h.SetSeed(hamtSeed)
+	writeHash(&h, reflect.ValueOf(&key).Elem())
+	return h.Sum64()
+}
+
+// mix64 scrambles the bits of an integer key (splitmix64 finalizer).
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// writeHash feeds a comparable value into h, consistently with the == operator.
+func writeHash(h *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	writeUint := func(x uint64) {
+		binary.LittleEndian.PutUint64(buf[:], x)
+		h.Write(buf[:])
+	}
+	writeFloat := func(f float64) {
+		if f == 0 {
+			f = 0 // +0 and -0 are equal
 		}
+		writeUint(math.Float64bits(f))
 	}
 
-	return &Map[K, V]{pairs: newPairs}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			writeUint(1)
+		} else {
+			writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeFloat(real(c))
+		writeFloat(imag(c))
+	case reflect.String:
+		h.WriteString(v.String())
+		h.WriteByte(0)
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint(uint64(v.Pointer()))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeHash(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			writeHash(h, v.Field(i))
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			writeUint(0)
+			return
+		}
+		elem := v.Elem()
+		h.WriteString(elem.Type().String())
+		writeHash(h, elem)
+	}
 }
 
 // Size returns the number of key-value pairs in the map.
 func (m *Map[K, V]) Size() int {
-	return len(m.pairs)
+	return m.size
 }
 
 // IsEmpty returns true if the map is empty.
 func (m *Map[K, V]) IsEmpty() bool {
-	return len(m.pairs) == 0
+	return m.size == 0
 }
 
 // Contains returns true if the map contains the key.
@@ -554,64 +1228,271 @@ func (m *Map[K, V]) Contains(key K) bool {
 
 // ForEach applies a function to each key-value pair.
 func (m *Map[K, V]) ForEach(f func(K, V)) {
-	for _, pair := range m.pairs {
+	m.root.forEach(func(pair Pair[K, V]) bool {
 		f(pair.Key, pair.Value)
-	}
+		return true
+	})
 }
 
 // Map applies a function to each value and returns a new map.
 func (m *Map[K, V]) Map(f func(V) V) *Map[K, V] {
 	result := EmptyMap[K, V]()
-	for _, pair := range m.pairs {
-		result = result.Set(pair.Key, f(pair.Value))
-	}
+	m.ForEach(func(key K, value V) {
+		result = result.Set(key, f(value))
+	})
 	return result
 }
 
 // Filter returns a new map containing only key-value pairs that satisfy the predicate.
 func (m *Map[K, V]) Filter(predicate func(K, V) bool) *Map[K, V] {
 	result := EmptyMap[K, V]()
-	for _, pair := range m.pairs {
-		if predicate(pair.Key, pair.Value) {
-			result = result.Set(pair.Key, pair.Value)
+	m.ForEach(func(key K, value V) {
+		if predicate(key, value) {
+			result = result.Set(key, value)
 		}
-	}
+	})
+	return result
+}
+
+// Merge returns a new map containing the keys of both maps.
+// When a key exists in both, combine is called with this map's value and the other's.
+func (m *Map[K, V]) Merge(other *Map[K, V], combine func(a, b V) V) *Map[K, V] {
+	result := m
+	other.ForEach(func(key K, value V) {
+		if existing, found := result.Get(key); found {
+			value = combine(existing, value)
+		}
+		result = result.Set(key, value)
+	})
 	return result
 }
 
 // Keys returns a slice of all keys in the map.
 func (m *Map[K, V]) Keys() []K {
-	keys := make([]K, len(m.pairs))
-	for i, pair := range m.pairs {
-		keys[i] = pair.Key
-	}
+	keys := make([]K, 0, m.size)
+	m.ForEach(func(key K, _ V) {
+		keys = append(keys, key)
+	})
 	return keys
 }
 
 // Values returns a slice of all values in the map.
 func (m *Map[K, V]) Values() []V {
-	values := make([]V, len(m.pairs))
-	for i, pair := range m.pairs {
-		values[i] = pair.Value
-	}
+	values := make([]V, 0, m.size)
+	m.ForEach(func(_ K, value V) {
+		values = append(values, value)
+	})
 	return values
 }
 
 // ToSlice converts the map to a slice of key-value pairs.
 func (m *Map[K, V]) ToSlice() []Pair[K, V] {
-	return m.pairs
+	pairs := make([]Pair[K, V], 0, m.size)
+	m.ForEach(func(key K, value V) {
+		pairs = append(pairs, Pair[K, V]{Key: key, Value: value})
+	})
+	return pairs
 }
 
 // String returns a string representation of the map.
 func (m *Map[K, V]) String() string {
 	var sb strings.Builder
 	sb.WriteString("Map{")
-	for i, pair := range m.pairs {
-		if i > 0 {
+	first := true
+	m.ForEach(func(key K, value V) {
+		if !first {
 			sb.WriteString(", ")
 		}
-		sb.WriteString(fmt.Sprintf("%v: %v", pair.Key, pair.Value))
+		sb.WriteString(fmt.Sprintf("%v: %v", key, value))
+		first = false
+	})
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// MapValuesTo applies a type-changing function to each value and returns a new map.
+func MapValuesTo[K comparable, V any, W any](m *Map[K, V], f func(V) W) *Map[K, W] {
+	result := EmptyMap[K, W]()
+	m.ForEach(func(key K, value V) {
+		result = result.Set(key, f(value))
+	})
+	return result
+}
+
+// MapKeys applies a function to each key and returns a new map.
+// If several keys map to the same new key, only one of their values is kept;
+// which one is unspecified because map iteration order is not defined.
+func MapKeys[K comparable, L comparable, V any](m *Map[K, V], f func(K) L) *Map[L, V] {
+	result := EmptyMap[L, V]()
+	m.ForEach(func(key K, value V) {
+		result = result.Set(f(key), value)
+	})
+	return result
+}
+
+// EqualMap returns true if both maps have the same keys mapped to equal values, regardless of internal order.
+func EqualMap[K comparable, V comparable](a, b *Map[K, V]) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+	return a.root.forEach(func(pair Pair[K, V]) bool {
+		value, found := b.Get(pair.Key)
+		return found && value == pair.Value
+	})
+}
+
+// OrderedMap is a persistent immutable map that iterates in insertion order.
+// Updating an existing key keeps its position; deleting and re-adding a key moves it to the end.
+type OrderedMap[K comparable, V any] struct {
+	index   *Map[K, int]
+	entries *Vector[orderedEntry[K, V]]
+}
+
+type orderedEntry[K comparable, V any] struct {
+	pair    Pair[K, V]
+	deleted bool
+}
+
+// EmptyOrderedMap creates an empty ordered map.
+func EmptyOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		index:   EmptyMap[K, int](),
+		entries: EmptyVector[orderedEntry[K, V]](),
+	}
+}
+
+// OrderedMapOf creates an ordered map from key-value pairs, in the given order.
+func OrderedMapOf[K comparable, V any](pairs ...Pair[K, V]) *OrderedMap[K, V] {
+	m := EmptyOrderedMap[K, V]()
+	for _, pair := range pairs {
+		m = m.Set(pair.Key, pair.Value)
 	}
+	return m
+}
+
+// Set adds or updates a key-value pair.
+// Returns a new map with the pair added/updated.
+func (m *OrderedMap[K, V]) Set(key K, value V) *OrderedMap[K, V] {
+	entry := orderedEntry[K, V]{pair: Pair[K, V]{Key: key, Value: value}}
+	if pos, found := m.index.Get(key); found {
+		return &OrderedMap[K, V]{
+			index:   m.index,
+			entries: m.entries.Set(pos, entry),
+		}
+	}
+	return &OrderedMap[K, V]{
+		index:   m.index.Set(key, m.entries.Length()),
+		entries: m.entries.Append(entry),
+	}
+}
+
+// Get returns the value for the given key.
+// Returns false as second return value if key not found.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if pos, found := m.index.Get(key); found {
+		return m.entries.Get(pos).pair.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes a key from the map.
+// Returns a new map without the key.
+func (m *OrderedMap[K, V]) Delete(key K) *OrderedMap[K, V] {
+	pos, found := m.index.Get(key)
+	if !found {
+		return m
+	}
+	result := &OrderedMap[K, V]{
+		index:   m.index.Delete(key),
+		entries: m.entries.Set(pos, orderedEntry[K, V]{deleted: true}),
+	}
+	if tombstones := result.entries.Length() - result.index.Size(); tombstones > result.index.Size() {
+		return result.compact()
+	}
+	return result
+}
+
+// compact rebuilds the entries without tombstones and re-indexes the keys,
+// so that deletes do not grow the backing vector without bound.
+func (m *OrderedMap[K, V]) compact() *OrderedMap[K, V] {
+	index := EmptyMap[K, int]()
+	entries := NewTransientVector[orderedEntry[K, V]]()
+	m.entries.ForEach(func(entry orderedEntry[K, V]) {
+		if !entry.deleted {
+			index = index.Set(entry.pair.Key, entries.Length())
+			entries.Push(entry)
+		}
+	})
+	return &OrderedMap[K, V]{
+		index:   index,
+		entries: entries.Persistent(),
+	}
+}
+
+// Size returns the number of key-value pairs in the map.
+func (m *OrderedMap[K, V]) Size() int {
+	return m.index.Size()
+}
+
+// IsEmpty returns true if the map is empty.
+func (m *OrderedMap[K, V]) IsEmpty() bool {
+	return m.index.IsEmpty()
+}
+
+// Contains returns true if the map contains the key.
+func (m *OrderedMap[K, V]) Contains(key K) bool {
+	return m.index.Contains(key)
+}
+
+// ForEach applies a function to each key-value pair in insertion order.
+func (m *OrderedMap[K, V]) ForEach(f func(K, V)) {
+	m.entries.ForEach(func(entry orderedEntry[K, V]) {
+		if !entry.deleted {
+			f(entry.pair.Key, entry.pair.Value)
+		}
+	})
+}
+
+// Keys returns a slice of all keys in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Size())
+	m.ForEach(func(key K, _ V) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+// Values returns a slice of all values in insertion order.
+func (m *OrderedMap[K, V]) Values() []V {
+	values := make([]V, 0, m.Size())
+	m.ForEach(func(_ K, value V) {
+		values = append(values, value)
+	})
+	return values
+}
+
+// ToSlice converts the map to a slice of key-value pairs in insertion order.
+func (m *OrderedMap[K, V]) ToSlice() []Pair[K, V] {
+	pairs := make([]Pair[K, V], 0, m.Size())
+	m.ForEach(func(key K, value V) {
+		pairs = append(pairs, Pair[K, V]{Key: key, Value: value})
+	})
+	return pairs
+}
+
+// String returns a string representation of the map in insertion order.
+func (m *OrderedMap[K, V]) String() string {
+	var sb strings.Builder
+	sb.WriteString("OrderedMap{")
+	first := true
+	m.ForEach(func(key K, value V) {
+		if !first {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(fmt.Sprintf("%v: %v", key, value))
+		first = false
+	})
 	sb.WriteString("}")
 	return sb.String()
 }
@@ -635,6 +1516,12 @@ func SetOf[T comparable](values ...T) *Set[T] {
 	return s
 }
 
+// SetFromSlice creates a set from the elements of a slice.
+// Duplicate elements are stored once.
+func SetFromSlice[T comparable](values []T) *Set[T] {
+	return SetOf(values...)
+}
+
 // Add adds an element to the set.
 // Returns a new set with the element added.
 func (s *Set[T]) Add(value T) *Set[T] {
@@ -694,6 +1581,63 @@ func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
 	return result
 }
 
+// SymmetricDifference returns a new set containing elements in exactly one of the two sets.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	return s.Difference(other).Union(other.Difference(s))
+}
+
+// IsSubset returns true if every element of this set is in the other set.
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+	return s.inner.root.forEach(func(pair Pair[T, struct{}]) bool {
+		return other.Contains(pair.Key)
+	})
+}
+
+// IsSuperset returns true if every element of the other set is in this set.
+func (s *Set[T]) IsSuperset(other *Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// IsDisjoint returns true if the sets have no elements in common.
+func (s *Set[T]) IsDisjoint(other *Set[T]) bool {
+	if s.Size() > other.Size() {
+		s, other = other, s
+	}
+	return s.inner.root.forEach(func(pair Pair[T, struct{}]) bool {
+		return !other.Contains(pair.Key)
+	})
+}
+
+// Filter returns a new set containing only the elements that satisfy the predicate.
+func (s *Set[T]) Filter(predicate func(T) bool) *Set[T] {
+	result := EmptySet[T]()
+	s.ForEach(func(value T) {
+		if predicate(value) {
+			result = result.Add(value)
+		}
+	})
+	return result
+}
+
+// Any returns true if at least one element satisfies the predicate.
+// It stops at the first match and returns false for an empty set.
+func (s *Set[T]) Any(predicate func(T) bool) bool {
+	return !s.inner.root.forEach(func(pair Pair[T, struct{}]) bool {
+		return !predicate(pair.Key)
+	})
+}
+
+// All returns true if every element satisfies the predicate.
+// It stops at the first failure and returns true for an empty set.
+func (s *Set[T]) All(predicate func(T) bool) bool {
+	return s.inner.root.forEach(func(pair Pair[T, struct{}]) bool {
+		return predicate(pair.Key)
+	})
+}
+
 // ForEach applies a function to each element.
 func (s *Set[T]) ForEach(f func(T)) {
 	s.inner.ForEach(func(key T, _ struct{}) {
@@ -706,6 +1650,18 @@ func (s *Set[T]) ToSlice() []T {
 	return s.inner.Keys()
 }
 
+// ToList converts the set to a list.
+// The order of the elements is unspecified.
+func (s *Set[T]) ToList() *List[T] {
+	return ListOf(s.ToSlice()...)
+}
+
+// ToVector converts the set to a vector.
+// The order of the elements is unspecified.
+func (s *Set[T]) ToVector() *Vector[T] {
+	return VectorOf(s.ToSlice()...)
+}
+
 // String returns a string representation of the set.
 func (s *Set[T]) String() string {
 	var sb strings.Builder
@@ -721,3 +1677,19 @@ func (s *Set[T]) String() string {
 	sb.WriteString("}")
 	return sb.String()
 }
+
+// MapSet applies a function to each element and returns a new set.
+// Distinct elements that map to the same value collapse into one, so the
+// result may be smaller than the input.
+func MapSet[T comparable, U comparable](s *Set[T], f func(T) U) *Set[U] {
+	result := EmptySet[U]()
+	s.ForEach(func(value T) {
+		result = result.Add(f(value))
+	})
+	return result
+}
+
+// EqualSet returns true if both sets contain exactly the same elements.
+func EqualSet[T comparable](a, b *Set[T]) bool {
+	return a.Size() == b.Size() && a.IsSubset(b)
+}
@@ -2,8 +2,11 @@
 package immutable_test
 
 import (
+	"fmt"
+	"math/rand"
 	"testing"
 
+	rust "github.com/dongrv/rust-go"
 	"github.com/dongrv/rust-go/immutable"
 )
 
@@ -102,6 +105,178 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestListGet(t *testing.T) {
+	list := immutable.ListOf(10, 20, 30, 40)
+
+	// Test first, middle and last
+	if v := list.Get(0); v.UnwrapOr(0) != 10 {
+		t.Errorf("Expected 10 at index 0, got %v", v)
+	}
+	if v := list.Get(2); v.UnwrapOr(0) != 30 {
+		t.Errorf("Expected 30 at index 2, got %v", v)
+	}
+	if v := list.Get(3); v.UnwrapOr(0) != 40 {
+		t.Errorf("Expected 40 at index 3, got %v", v)
+	}
+
+	// Test out of range
+	if list.Get(4).IsSome() {
+		t.Error("Expected None for index past the end")
+	}
+	if list.Get(-1).IsSome() {
+		t.Error("Expected None for negative index")
+	}
+	if immutable.EmptyList[int]().Get(0).IsSome() {
+		t.Error("Expected None for empty list")
+	}
+}
+
+func TestListTakeDrop(t *testing.T) {
+	list := immutable.ListOf(1, 2, 3, 4, 5)
+
+	// Test Take
+	taken := list.Take(2).ToSlice()
+	if len(taken) != 2 || taken[0] != 1 || taken[1] != 2 {
+		t.Errorf("Expected [1 2], got %v", taken)
+	}
+	if !list.Take(0).IsEmpty() || !list.Take(-1).IsEmpty() {
+		t.Error("Take with n <= 0 should return an empty list")
+	}
+	if list.Take(5) != list || list.Take(10) != list {
+		t.Error("Take with n >= size should return the list itself")
+	}
+
+	// Test Drop
+	dropped := list.Drop(3).ToSlice()
+	if len(dropped) != 2 || dropped[0] != 4 || dropped[1] != 5 {
+		t.Errorf("Expected [4 5], got %v", dropped)
+	}
+	if list.Drop(3).Size() != 2 {
+		t.Errorf("Expected dropped size 2, got %d", list.Drop(3).Size())
+	}
+	if list.Drop(0) != list || list.Drop(-1) != list {
+		t.Error("Drop with n <= 0 should return the list itself")
+	}
+	if !list.Drop(5).IsEmpty() || !list.Drop(10).IsEmpty() {
+		t.Error("Drop with n >= size should return an empty list")
+	}
+
+	// Original should be unchanged
+	if list.Size() != 5 {
+		t.Errorf("Original list should be unchanged, got size %d", list.Size())
+	}
+}
+
+func TestListTypeChangingTransforms(t *testing.T) {
+	list := immutable.ListOf(1, 2, 3)
+
+	// Test MapListTo
+	mapped := immutable.MapListTo(list, func(x int) string { return fmt.Sprintf("n%d", x) }).ToSlice()
+	expectedMapped := []string{"n1", "n2", "n3"}
+	if len(mapped) != len(expectedMapped) {
+		t.Fatalf("Expected %d elements, got %d", len(expectedMapped), len(mapped))
+	}
+	for i, v := range expectedMapped {
+		if mapped[i] != v {
+			t.Errorf("Expected %s at index %d, got %s", v, i, mapped[i])
+		}
+	}
+
+	// Test FlatMapList
+	flat := immutable.FlatMapList(list, func(x int) *immutable.List[string] {
+		if x == 2 {
+			return immutable.EmptyList[string]()
+		}
+		return immutable.ListOf(fmt.Sprint(x), fmt.Sprint(x*10))
+	})
+	expectedFlat := []string{"1", "10", "3", "30"}
+	if flat.Size() != len(expectedFlat) {
+		t.Fatalf("Expected size %d, got %d", len(expectedFlat), flat.Size())
+	}
+	for i, v := range flat.ToSlice() {
+		if v != expectedFlat[i] {
+			t.Errorf("Expected %s at index %d, got %s", expectedFlat[i], i, v)
+		}
+	}
+}
+
+func TestListSearch(t *testing.T) {
+	list := immutable.ListOf("a", "b", "c", "b")
+
+	// Test Find
+	if v := list.Find(func(s string) bool { return s > "a" }); v.UnwrapOr("") != "b" {
+		t.Errorf("Expected to find b, got %v", v)
+	}
+	if list.Find(func(s string) bool { return s == "z" }).IsSome() {
+		t.Error("Expected Find to return None when nothing matches")
+	}
+
+	// Test ContainsList
+	if !immutable.ContainsList(list, "c") {
+		t.Error("Expected list to contain c")
+	}
+	if immutable.ContainsList(list, "z") {
+		t.Error("Expected list not to contain z")
+	}
+
+	// Test IndexOfList returns the first occurrence
+	if idx := immutable.IndexOfList(list, "b"); idx.UnwrapOr(-1) != 1 {
+		t.Errorf("Expected index 1, got %v", idx)
+	}
+	if immutable.IndexOfList(list, "z").IsSome() {
+		t.Error("Expected IndexOfList to return None for a missing value")
+	}
+}
+
+func TestEqualList(t *testing.T) {
+	// Test equal lists built differently
+	a := immutable.ListOf(1, 2, 3)
+	b := immutable.EmptyList[int]().Cons(3).Cons(2).Cons(1)
+	if !immutable.EqualList(a, b) {
+		t.Error("Expected lists with the same elements to be equal")
+	}
+	if !immutable.EqualList(a, a.Tail().Cons(1)) {
+		t.Error("Expected lists sharing structure to be equal")
+	}
+
+	// Test different lengths and values
+	if immutable.EqualList(a, immutable.ListOf(1, 2)) {
+		t.Error("Expected lists of different lengths to be unequal")
+	}
+	if immutable.EqualList(a, immutable.ListOf(1, 2, 4)) {
+		t.Error("Expected lists with different elements to be unequal")
+	}
+
+	// Test empty lists
+	if !immutable.EqualList(immutable.EmptyList[int](), immutable.ListOf[int]()) {
+		t.Error("Expected empty lists to be equal")
+	}
+	if immutable.EqualList(immutable.EmptyList[int](), a) {
+		t.Error("Expected empty and non-empty lists to be unequal")
+	}
+}
+
+func TestListIter(t *testing.T) {
+	list := immutable.ListOf(1, 2, 3, 4, 5, 6)
+
+	// Test piping through iterator combinators
+	evens := rust.Collect(rust.Filter(list.Iter(), func(x int) bool { return x%2 == 0 }))
+	expected := []int{2, 4, 6}
+	if len(evens) != len(expected) {
+		t.Fatalf("Expected %d elements, got %d", len(expected), len(evens))
+	}
+	for i, v := range expected {
+		if evens[i] != v {
+			t.Errorf("Expected %d at index %d, got %d", v, i, evens[i])
+		}
+	}
+
+	// Test empty list
+	if immutable.EmptyList[int]().Iter().Next().IsSome() {
+		t.Error("Expected iterator over empty list to return None")
+	}
+}
+
 func TestVector(t *testing.T) {
 	// Test EmptyVector
 	vector := immutable.EmptyVector[int]()
@@ -172,6 +347,252 @@ func TestVector(t *testing.T) {
 			t.Errorf("Expected %d at index %d, got %d", i+1, i, v)
 		}
 	}
+
+	// Test growth beyond a single tree level
+	large := immutable.EmptyVector[int]()
+	for i := 0; i < 2000; i++ {
+		large = large.Append(i)
+	}
+	for i := 0; i < large.Length(); i++ {
+		if large.Get(i) != i {
+			t.Fatalf("Expected %d at index %d, got %d", i, i, large.Get(i))
+		}
+	}
+	largeUpdated := large.Set(1000, -1)
+	if largeUpdated.Get(1000) != -1 || large.Get(1000) != 1000 {
+		t.Error("Set on a large vector should not affect the original")
+	}
+}
+
+func TestVectorPop(t *testing.T) {
+	// Test empty vector
+	empty := immutable.EmptyVector[int]()
+	popped, value := empty.Pop()
+	if value.IsSome() || !popped.IsEmpty() {
+		t.Error("Pop on empty vector should return None and an empty vector")
+	}
+
+	// Test small vector
+	small := immutable.VectorOf(1, 2, 3)
+	rest, value := small.Pop()
+	if value.UnwrapOr(0) != 3 || rest.Length() != 2 || rest.Get(1) != 2 {
+		t.Errorf("Expected to pop 3 leaving [1 2], got %v and %v", value, rest)
+	}
+	if small.Length() != 3 {
+		t.Error("Original vector should be unchanged after Pop")
+	}
+
+	// Test popping across node boundaries
+	const n = 1100
+	vector := immutable.EmptyVector[int]()
+	for i := 0; i < n; i++ {
+		vector = vector.Append(i)
+	}
+	current := vector
+	for i := n - 1; i >= 0; i-- {
+		var v rust.Option[int]
+		current, v = current.Pop()
+		if v.UnwrapOr(-1) != i {
+			t.Fatalf("Expected to pop %d, got %v", i, v)
+		}
+		if current.Length() != i {
+			t.Fatalf("Expected length %d after pop, got %d", i, current.Length())
+		}
+		if i > 0 && current.Get(i-1) != i-1 {
+			t.Fatalf("Expected last element %d after pop, got %d", i-1, current.Get(i-1))
+		}
+	}
+	if !current.IsEmpty() {
+		t.Error("Expected vector to be empty after popping every element")
+	}
+
+	// Test appending again after popping across a boundary
+	shrunk := vector
+	for i := 0; i < 40; i++ {
+		shrunk, _ = shrunk.Pop()
+	}
+	regrown := shrunk.Append(-1)
+	if regrown.Get(n-40) != -1 || regrown.Get(n-41) != n-41 {
+		t.Error("Expected Append after Pop to extend the vector correctly")
+	}
+	if vector.Get(n-40) != n-40 {
+		t.Error("Original vector should be unchanged")
+	}
+}
+
+func TestVectorSlice(t *testing.T) {
+	vector := immutable.EmptyVector[int]()
+	for i := 0; i < 100; i++ {
+		vector = vector.Append(i)
+	}
+
+	// Test full slice
+	if full := vector.Slice(0, 100); full.Length() != 100 || full.Get(99) != 99 {
+		t.Error("Expected full slice to contain every element")
+	}
+
+	// Test sub-range across node boundaries
+	sub := vector.Slice(30, 70)
+	if sub.Length() != 40 {
+		t.Fatalf("Expected length 40, got %d", sub.Length())
+	}
+	for i := 0; i < sub.Length(); i++ {
+		if sub.Get(i) != i+30 {
+			t.Errorf("Expected %d at index %d, got %d", i+30, i, sub.Get(i))
+		}
+	}
+
+	// Test empty slices
+	if !vector.Slice(10, 10).IsEmpty() || !vector.Slice(100, 100).IsEmpty() {
+		t.Error("Expected empty slices for equal bounds")
+	}
+
+	// Test out-of-range indices
+	for _, r := range [][2]int{{-1, 5}, {0, 101}, {20, 10}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected Slice(%d, %d) to panic", r[0], r[1])
+				}
+			}()
+			vector.Slice(r[0], r[1])
+		}()
+	}
+}
+
+func TestTransientVector(t *testing.T) {
+	for _, n := range []int{0, 1, 32, 33, 1024, 1056, 1057, 5000} {
+		transient := immutable.NewTransientVector[int]()
+		for i := 0; i < n; i++ {
+			transient.Push(i)
+		}
+		if transient.Length() != n {
+			t.Errorf("Expected transient length %d, got %d", n, transient.Length())
+		}
+
+		vector := transient.Persistent()
+		if vector.Length() != n {
+			t.Fatalf("Expected length %d, got %d", n, vector.Length())
+		}
+		for i := 0; i < n; i++ {
+			if vector.Get(i) != i {
+				t.Fatalf("Expected %d at index %d for size %d, got %d", i, i, n, vector.Get(i))
+			}
+		}
+
+		// Test the built vector behaves like one built with Append
+		grown := vector.Append(-1)
+		if grown.Get(n) != -1 || (n > 0 && grown.Get(n-1) != n-1) {
+			t.Errorf("Expected Append after Persistent to work for size %d", n)
+		}
+		if n > 0 {
+			shrunk, last := vector.Pop()
+			if last.UnwrapOr(-1) != n-1 || shrunk.Length() != n-1 {
+				t.Errorf("Expected Pop after Persistent to work for size %d", n)
+			}
+		}
+
+		// Test later pushes do not affect the persistent vector
+		transient.Push(-2)
+		if vector.Length() != n {
+			t.Errorf("Persistent vector should not change after further pushes")
+		}
+	}
+}
+
+func BenchmarkVectorAppend(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v := immutable.EmptyVector[int]()
+		for j := 0; j < 10000; j++ {
+			v = v.Append(j)
+		}
+	}
+}
+
+func BenchmarkVectorOf(b *testing.B) {
+	values := make([]int, 10000)
+	for i := range values {
+		values[i] = i
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		immutable.VectorOf(values...)
+	}
+}
+
+func TestVectorIterAndSearch(t *testing.T) {
+	vector := immutable.EmptyVector[int]()
+	for i := 0; i < 100; i++ {
+		vector = vector.Append(i % 50)
+	}
+
+	// Test iteration order across node boundaries
+	collected := rust.Collect(vector.Iter())
+	if len(collected) != 100 {
+		t.Fatalf("Expected 100 elements, got %d", len(collected))
+	}
+	for i, v := range collected {
+		if v != i%50 {
+			t.Errorf("Expected %d at index %d, got %d", i%50, i, v)
+		}
+	}
+
+	// Test Find
+	if v := vector.Find(func(x int) bool { return x > 40 }); v.UnwrapOr(-1) != 41 {
+		t.Errorf("Expected to find 41, got %v", v)
+	}
+	if vector.Find(func(x int) bool { return x > 100 }).IsSome() {
+		t.Error("Expected Find to return None when nothing matches")
+	}
+
+	// Test IndexOfVector returns the first occurrence, including one in the tree
+	if idx := immutable.IndexOfVector(vector, 45); idx.UnwrapOr(-1) != 45 {
+		t.Errorf("Expected index 45, got %v", idx)
+	}
+	if immutable.IndexOfVector(vector, 77).IsSome() {
+		t.Error("Expected IndexOfVector to return None for a missing value")
+	}
+
+	// Test ContainsVector
+	if !immutable.ContainsVector(vector, 49) {
+		t.Error("Expected vector to contain 49")
+	}
+	if immutable.ContainsVector(vector, -1) {
+		t.Error("Expected vector not to contain -1")
+	}
+}
+
+func TestVectorUpdate(t *testing.T) {
+	vector := immutable.EmptyVector[int]()
+	for i := 0; i < 40; i++ {
+		vector = vector.Append(i)
+	}
+	double := func(x int) int { return x * 2 }
+
+	// Test update in the tree portion
+	updated := vector.Update(5, double)
+	if updated.Get(5) != 10 {
+		t.Errorf("Expected 10 at index 5, got %d", updated.Get(5))
+	}
+
+	// Test update in the tail portion
+	updated = updated.Update(39, double)
+	if updated.Get(39) != 78 {
+		t.Errorf("Expected 78 at index 39, got %d", updated.Get(39))
+	}
+
+	// Original should be unchanged
+	if vector.Get(5) != 5 || vector.Get(39) != 39 {
+		t.Error("Original vector should be unchanged after Update")
+	}
+
+	// Test out-of-range panics
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Update with out-of-range index to panic")
+		}
+	}()
+	vector.Update(40, double)
 }
 
 func TestMap(t *testing.T) {
@@ -310,6 +731,360 @@ func TestMap(t *testing.T) {
 	}
 }
 
+func TestMapMatchesBuiltinMap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	expected := make(map[int]int)
+	m := immutable.EmptyMap[int, int]()
+	snapshots := []*immutable.Map[int, int]{}
+
+	// Test random inserts, updates and deletes against a built-in map
+	for i := 0; i < 20000; i++ {
+		key := rng.Intn(5000)
+		if rng.Intn(3) == 0 {
+			delete(expected, key)
+			m = m.Delete(key)
+		} else {
+			expected[key] = i
+			m = m.Set(key, i)
+		}
+		if i == 10000 {
+			snapshots = append(snapshots, m)
+		}
+	}
+
+	if m.Size() != len(expected) {
+		t.Fatalf("Expected size %d, got %d", len(expected), m.Size())
+	}
+	for key, value := range expected {
+		if got, ok := m.Get(key); !ok || got != value {
+			t.Fatalf("Expected (%d, true) for key %d, got (%d, %v)", value, key, got, ok)
+		}
+	}
+	seen := 0
+	m.ForEach(func(key, value int) {
+		seen++
+		if expected[key] != value {
+			t.Errorf("Unexpected pair %d: %d", key, value)
+		}
+	})
+	if seen != len(expected) {
+		t.Errorf("Expected ForEach to visit %d pairs, got %d", len(expected), seen)
+	}
+
+	// Test snapshots are unaffected by later updates
+	if snapshots[0].Size() == 0 {
+		t.Error("Snapshot should keep its contents")
+	}
+
+	// Test deleting every key empties the map
+	for key := range expected {
+		m = m.Delete(key)
+	}
+	if !m.IsEmpty() || len(m.Keys()) != 0 {
+		t.Errorf("Expected empty map after deleting every key, got size %d", m.Size())
+	}
+}
+
+func TestMapKeyKinds(t *testing.T) {
+	type point struct {
+		X, Y int
+		Name string
+	}
+
+	// Test struct keys
+	points := immutable.EmptyMap[point, string]().
+		Set(point{1, 2, "a"}, "first").
+		Set(point{1, 2, "a"}, "updated").
+		Set(point{2, 1, "a"}, "second")
+	if points.Size() != 2 {
+		t.Errorf("Expected 2 struct keys, got %d", points.Size())
+	}
+	if v, _ := points.Get(point{1, 2, "a"}); v != "updated" {
+		t.Errorf("Expected updated, got %s", v)
+	}
+
+	// Test interface keys with different dynamic types
+	mixed := immutable.EmptyMap[any, int]().Set(1, 1).Set("1", 2).Set(int64(1), 3)
+	if mixed.Size() != 3 {
+		t.Errorf("Expected 3 distinct interface keys, got %d", mixed.Size())
+	}
+	if v, ok := mixed.Get("1"); !ok || v != 2 {
+		t.Errorf("Expected (2, true) for key \"1\", got (%d, %v)", v, ok)
+	}
+
+	// Test pointer keys compare by identity
+	a, b := &point{}, &point{}
+	pointers := immutable.EmptyMap[*point, int]().Set(a, 1).Set(b, 2)
+	a.X = 42
+	if v, ok := pointers.Get(a); !ok || v != 1 {
+		t.Errorf("Expected pointer key lookup to survive mutation of the pointee, got (%d, %v)", v, ok)
+	}
+
+	// Test positive and negative zero are the same key
+	floats := immutable.EmptyMap[float64, string]().Set(0.0, "zero")
+	negZero := 0.0
+	negZero = -negZero
+	if v, ok := floats.Get(negZero); !ok || v != "zero" {
+		t.Errorf("Expected -0 to find the 0 key, got (%s, %v)", v, ok)
+	}
+}
+
+func BenchmarkMapSet10k(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		m := immutable.EmptyMap[int, int]()
+		for j := 0; j < 10000; j++ {
+			m = m.Set(j, j)
+		}
+	}
+}
+
+func BenchmarkMapGet10k(b *testing.B) {
+	m := immutable.EmptyMap[int, int]()
+	for j := 0; j < 10000; j++ {
+		m = m.Set(j, j)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(i % 10000)
+	}
+}
+
+func TestMapMerge(t *testing.T) {
+	sum := func(a, b int) int { return a + b }
+
+	// Test disjoint maps
+	a := immutable.MapOf(immutable.PairOf("x", 1), immutable.PairOf("y", 2))
+	b := immutable.MapOf(immutable.PairOf("z", 3))
+	merged := a.Merge(b, sum)
+	if merged.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", merged.Size())
+	}
+	if v, _ := merged.Get("z"); v != 3 {
+		t.Errorf("Expected 3 for key z, got %d", v)
+	}
+
+	// Test overlapping keys
+	c := immutable.MapOf(immutable.PairOf("y", 10), immutable.PairOf("w", 4))
+	merged = a.Merge(c, sum)
+	if merged.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", merged.Size())
+	}
+	if v, _ := merged.Get("y"); v != 12 {
+		t.Errorf("Expected combined value 12 for key y, got %d", v)
+	}
+	keepOther := a.Merge(c, func(_, b int) int { return b })
+	if v, _ := keepOther.Get("y"); v != 10 {
+		t.Errorf("Expected combine to receive the other value second, got %d", v)
+	}
+
+	// Originals should be unchanged
+	if a.Size() != 2 || c.Size() != 2 {
+		t.Error("Merge should not modify the original maps")
+	}
+	if v, _ := a.Get("y"); v != 2 {
+		t.Errorf("Original value should be unchanged, got %d", v)
+	}
+}
+
+func TestMapGetOrDefaultAndGetOption(t *testing.T) {
+	m := immutable.MapOf(immutable.PairOf("a", 1), immutable.PairOf("zero", 0))
+
+	// Test GetOrDefault
+	if v := m.GetOrDefault("a", 99); v != 1 {
+		t.Errorf("Expected 1 for present key, got %d", v)
+	}
+	if v := m.GetOrDefault("zero", 99); v != 0 {
+		t.Errorf("Expected stored zero value for present key, got %d", v)
+	}
+	if v := m.GetOrDefault("missing", 99); v != 99 {
+		t.Errorf("Expected default 99 for absent key, got %d", v)
+	}
+
+	// Test GetOption
+	if v := m.GetOption("a"); v.UnwrapOr(-1) != 1 {
+		t.Errorf("Expected Some(1), got %v", v)
+	}
+	if m.GetOption("missing").IsSome() {
+		t.Error("Expected None for absent key")
+	}
+}
+
+func TestMapUpdate(t *testing.T) {
+	m := immutable.MapOf(immutable.PairOf("hits", 1))
+	increment := func(v rust.Option[int]) rust.Option[int] {
+		return rust.Some(v.UnwrapOr(0) + 1)
+	}
+
+	// Test inserting a new key
+	inserted := m.Update("misses", increment)
+	if v, ok := inserted.Get("misses"); !ok || v != 1 {
+		t.Errorf("Expected (1, true) for new key, got (%d, %v)", v, ok)
+	}
+
+	// Test modifying an existing key
+	modified := m.Update("hits", increment)
+	if v, _ := modified.Get("hits"); v != 2 {
+		t.Errorf("Expected 2 after increment, got %d", v)
+	}
+
+	// Test deleting by returning None
+	deleted := m.Update("hits", func(rust.Option[int]) rust.Option[int] { return rust.None[int]() })
+	if deleted.Contains("hits") || deleted.Size() != 0 {
+		t.Error("Expected key to be removed when f returns None")
+	}
+
+	// Original should be unchanged
+	if v, _ := m.Get("hits"); v != 1 || m.Size() != 1 {
+		t.Error("Update should not modify the original map")
+	}
+}
+
+func TestMapTypeChangingTransforms(t *testing.T) {
+	m := immutable.MapOf(immutable.PairOf(1, "one"), immutable.PairOf(2, "two"), immutable.PairOf(3, "three"))
+
+	// Test MapValuesTo
+	lengths := immutable.MapValuesTo(m, func(s string) int { return len(s) })
+	if lengths.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", lengths.Size())
+	}
+	if v, _ := lengths.Get(3); v != 5 {
+		t.Errorf("Expected length 5 for key 3, got %d", v)
+	}
+
+	// Test MapKeys
+	named := immutable.MapKeys(m, func(k int) string { return fmt.Sprintf("k%d", k) })
+	if v, ok := named.Get("k2"); !ok || v != "two" {
+		t.Errorf("Expected (two, true) for key k2, got (%s, %v)", v, ok)
+	}
+
+	// Test MapKeys collisions keep a single entry
+	parity := immutable.MapKeys(m, func(k int) bool { return k%2 == 0 })
+	if parity.Size() != 2 {
+		t.Errorf("Expected colliding keys to collapse to 2 entries, got %d", parity.Size())
+	}
+	if v, _ := parity.Get(true); v != "two" {
+		t.Errorf("Expected two for the only even key, got %s", v)
+	}
+}
+
+func TestOrderedMap(t *testing.T) {
+	// Test insertion order
+	m := immutable.EmptyOrderedMap[string, int]().
+		Set("c", 3).
+		Set("a", 1).
+		Set("b", 2)
+	if m.String() != "OrderedMap{c: 3, a: 1, b: 2}" {
+		t.Errorf("Expected insertion order, got '%s'", m.String())
+	}
+
+	// Test updates keep their position
+	updated := m.Set("a", 10)
+	keys := updated.Keys()
+	expectedKeys := []string{"c", "a", "b"}
+	for i, k := range expectedKeys {
+		if keys[i] != k {
+			t.Errorf("Expected key %s at index %d, got %s", k, i, keys[i])
+		}
+	}
+	values := updated.Values()
+	expectedValues := []int{3, 10, 2}
+	for i, v := range expectedValues {
+		if values[i] != v {
+			t.Errorf("Expected value %d at index %d, got %d", v, i, values[i])
+		}
+	}
+
+	// Test deletes and re-adding moves a key to the end
+	deleted := updated.Delete("c")
+	if deleted.Size() != 2 || deleted.Contains("c") {
+		t.Errorf("Expected c to be deleted, got %s", deleted)
+	}
+	if deleted.String() != "OrderedMap{a: 10, b: 2}" {
+		t.Errorf("Expected 'OrderedMap{a: 10, b: 2}', got '%s'", deleted.String())
+	}
+	readded := deleted.Set("c", 30)
+	if readded.String() != "OrderedMap{a: 10, b: 2, c: 30}" {
+		t.Errorf("Expected re-added key at the end, got '%s'", readded.String())
+	}
+
+	// Test Get and ToSlice
+	if v, ok := readded.Get("c"); !ok || v != 30 {
+		t.Errorf("Expected (30, true), got (%d, %v)", v, ok)
+	}
+	if _, ok := readded.Get("missing"); ok {
+		t.Error("Expected false for missing key")
+	}
+	pairs := immutable.OrderedMapOf(immutable.PairOf(2, "x"), immutable.PairOf(1, "y")).ToSlice()
+	if len(pairs) != 2 || pairs[0].Key != 2 || pairs[1].Key != 1 {
+		t.Errorf("Expected pairs in insertion order, got %v", pairs)
+	}
+
+	// Originals should be unchanged
+	if m.String() != "OrderedMap{c: 3, a: 1, b: 2}" {
+		t.Errorf("Original map should be unchanged, got '%s'", m.String())
+	}
+	if !immutable.EmptyOrderedMap[int, int]().IsEmpty() {
+		t.Error("EmptyOrderedMap should be empty")
+	}
+}
+
+func TestOrderedMapChurn(t *testing.T) {
+	// Test repeated set and delete keeps the backing vector bounded
+	m := immutable.EmptyOrderedMap[int, int]()
+	for i := 0; i < 10; i++ {
+		m = m.Set(i, i)
+	}
+	for i := 10; i < 10000; i++ {
+		m = m.Delete(i-10).Set(i, i)
+		if n := immutable.OrderedMapBackingLength(m); n > 2*m.Size()+1 {
+			t.Fatalf("Expected backing length at most %d, got %d", 2*m.Size()+1, n)
+		}
+	}
+
+	// Test order and lookups survive compaction
+	expected := []int{9990, 9991, 9992, 9993, 9994, 9995, 9996, 9997, 9998, 9999}
+	keys := m.Keys()
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected %d keys, got %d", len(expected), len(keys))
+	}
+	for i, key := range keys {
+		if key != expected[i] {
+			t.Errorf("Expected key %d at position %d, got %d", expected[i], i, key)
+		}
+		if value, found := m.Get(key); !found || value != key {
+			t.Errorf("Expected %d for key %d, got %d, %v", key, key, value, found)
+		}
+	}
+	if m.Contains(0) {
+		t.Error("Deleted key should not be present")
+	}
+}
+
+func TestEqualMap(t *testing.T) {
+	// Test equal maps built in different orders
+	a := immutable.MapOf(immutable.PairOf("x", 1), immutable.PairOf("y", 2), immutable.PairOf("z", 3))
+	b := immutable.EmptyMap[string, int]().Set("z", 3).Set("x", 1).Set("y", 2)
+	if !immutable.EqualMap(a, b) {
+		t.Error("Expected maps with the same pairs to be equal")
+	}
+
+	// Test maps differing by one value or one key
+	if immutable.EqualMap(a, b.Set("y", 20)) {
+		t.Error("Expected maps differing by one value to be unequal")
+	}
+	if immutable.EqualMap(a, b.Delete("z").Set("w", 3)) {
+		t.Error("Expected maps differing by one key to be unequal")
+	}
+	if immutable.EqualMap(a, b.Delete("z")) {
+		t.Error("Expected maps of different sizes to be unequal")
+	}
+
+	// Test empty maps
+	if !immutable.EqualMap(immutable.EmptyMap[string, int](), a.Delete("x").Delete("y").Delete("z")) {
+		t.Error("Expected empty maps to be equal")
+	}
+}
+
 func TestSet(t *testing.T) {
 	// Test EmptySet
 	s := immutable.EmptySet[int]()
@@ -418,6 +1193,171 @@ func TestSet(t *testing.T) {
 	}
 }
 
+func TestSetSymmetricDifference(t *testing.T) {
+	// Test disjoint sets
+	disjoint := immutable.SetOf(1, 2).SymmetricDifference(immutable.SetOf(3, 4))
+	if disjoint.Size() != 4 {
+		t.Errorf("Expected size 4 for disjoint sets, got %d", disjoint.Size())
+	}
+
+	// Test identical sets
+	same := immutable.SetOf(1, 2, 3).SymmetricDifference(immutable.SetOf(3, 2, 1))
+	if !same.IsEmpty() {
+		t.Errorf("Expected empty result for identical sets, got %s", same)
+	}
+
+	// Test partial overlap
+	partial := immutable.SetOf(1, 2, 3).SymmetricDifference(immutable.SetOf(2, 3, 4))
+	if partial.Size() != 2 || !partial.Contains(1) || !partial.Contains(4) {
+		t.Errorf("Expected {1, 4}, got %s", partial)
+	}
+}
+
+func TestSetRelations(t *testing.T) {
+	small := immutable.SetOf(1, 2)
+	large := immutable.SetOf(1, 2, 3)
+	other := immutable.SetOf(4, 5)
+	empty := immutable.EmptySet[int]()
+
+	// Test IsSubset
+	if !small.IsSubset(large) || large.IsSubset(small) {
+		t.Error("Expected {1, 2} to be a subset of {1, 2, 3} and not vice versa")
+	}
+	if !large.IsSubset(large) {
+		t.Error("Expected a set to be a subset of itself")
+	}
+
+	// Test IsSuperset
+	if !large.IsSuperset(small) || small.IsSuperset(large) {
+		t.Error("Expected {1, 2, 3} to be a superset of {1, 2} and not vice versa")
+	}
+
+	// Test IsDisjoint
+	if !small.IsDisjoint(other) || small.IsDisjoint(large) {
+		t.Error("Expected {1, 2} to be disjoint from {4, 5} but not from {1, 2, 3}")
+	}
+
+	// Test empty set edge cases
+	if !empty.IsSubset(small) || !small.IsSuperset(empty) {
+		t.Error("Expected the empty set to be a subset of every set")
+	}
+	if small.IsSubset(empty) || empty.IsSuperset(small) {
+		t.Error("Expected a non-empty set not to be a subset of the empty set")
+	}
+	if !empty.IsDisjoint(small) || !empty.IsDisjoint(empty) {
+		t.Error("Expected the empty set to be disjoint from every set")
+	}
+}
+
+func TestSetTransforms(t *testing.T) {
+	set := immutable.SetOf(1, 2, 3, 4, 5, 6)
+
+	// Test Filter
+	even := set.Filter(func(x int) bool { return x%2 == 0 })
+	if even.Size() != 3 || !even.Contains(2) || !even.Contains(4) || !even.Contains(6) {
+		t.Errorf("Expected {2, 4, 6}, got %s", even)
+	}
+	if set.Size() != 6 {
+		t.Error("Original set should not be modified")
+	}
+	if !immutable.EmptySet[int]().Filter(func(int) bool { return true }).IsEmpty() {
+		t.Error("Filter of empty set should be empty")
+	}
+
+	// Test MapSet
+	labels := immutable.MapSet(immutable.SetOf(1, 2, 3), func(x int) string { return fmt.Sprintf("n%d", x) })
+	if labels.Size() != 3 || !labels.Contains("n1") || !labels.Contains("n3") {
+		t.Errorf("Expected {n1, n2, n3}, got %s", labels)
+	}
+
+	// Test MapSet with colliding results
+	parity := immutable.MapSet(set, func(x int) bool { return x%2 == 0 })
+	if parity.Size() != 2 || !parity.Contains(true) || !parity.Contains(false) {
+		t.Errorf("Expected colliding elements to collapse to {false, true}, got %s", parity)
+	}
+}
+
+func TestSetConversions(t *testing.T) {
+	// Test EqualSet with different insertion orders
+	a := immutable.SetOf(1, 2, 3, 4)
+	b := immutable.SetOf(4, 3, 2, 1)
+	if !immutable.EqualSet(a, b) {
+		t.Error("Expected sets built in different orders to be equal")
+	}
+	if immutable.EqualSet(a, b.Remove(4)) || immutable.EqualSet(a, b.Remove(4).Add(5)) {
+		t.Error("Expected sets with different elements to be unequal")
+	}
+	if !immutable.EqualSet(immutable.EmptySet[int](), immutable.EmptySet[int]()) {
+		t.Error("Expected empty sets to be equal")
+	}
+
+	// Test SetFromSlice
+	fromSlice := immutable.SetFromSlice([]int{3, 1, 3, 2, 1})
+	if !immutable.EqualSet(fromSlice, immutable.SetOf(1, 2, 3)) {
+		t.Errorf("Expected {1, 2, 3}, got %s", fromSlice)
+	}
+
+	// Test ToList round trip
+	list := a.ToList()
+	if list.Size() != 4 {
+		t.Errorf("Expected list size 4, got %d", list.Size())
+	}
+	if !immutable.EqualSet(immutable.SetFromSlice(list.ToSlice()), a) {
+		t.Error("Expected ToList round trip to preserve elements")
+	}
+
+	// Test ToVector round trip
+	vector := a.ToVector()
+	if vector.Length() != 4 {
+		t.Errorf("Expected vector length 4, got %d", vector.Length())
+	}
+	if !immutable.EqualSet(immutable.SetFromSlice(vector.ToSlice()), a) {
+		t.Error("Expected ToVector round trip to preserve elements")
+	}
+}
+
+func TestSetAnyAll(t *testing.T) {
+	set := immutable.SetOf(2, 4, 6, 7)
+	isEven := func(x int) bool { return x%2 == 0 }
+
+	// Test Any
+	if !set.Any(isEven) {
+		t.Error("Expected Any to find an even element")
+	}
+	if set.Any(func(x int) bool { return x > 10 }) {
+		t.Error("Expected Any to be false when no element matches")
+	}
+
+	// Test All
+	if set.All(isEven) {
+		t.Error("Expected All to be false when 7 is present")
+	}
+	if !set.All(func(x int) bool { return x > 0 }) {
+		t.Error("Expected All to be true when every element matches")
+	}
+
+	// Test short-circuiting
+	calls := 0
+	set.Any(func(int) bool { calls++; return true })
+	if calls != 1 {
+		t.Errorf("Expected Any to stop after the first match, got %d calls", calls)
+	}
+	calls = 0
+	set.All(func(int) bool { calls++; return false })
+	if calls != 1 {
+		t.Errorf("Expected All to stop after the first failure, got %d calls", calls)
+	}
+
+	// Test empty set
+	empty := immutable.EmptySet[int]()
+	if empty.Any(isEven) {
+		t.Error("Expected Any on an empty set to be false")
+	}
+	if !empty.All(isEven) {
+		t.Error("Expected All on an empty set to be true")
+	}
+}
+
 func TestImmutableProperty(t *testing.T) {
 	// Test that operations return new instances
 	list1 := immutable.ListOf(1, 2, 3)
@@ -1,18 +1,40 @@
 // package rust provides Rust-like programming constructs for Go
 package rust
 
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
+)
+
 // Iterator is the trait for Rust-like iterators
 type Iterator[T any] interface {
 	// Next returns the next element in the iterator
 	Next() Option[T]
 }
 
+// Number is the constraint for numeric element types
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
 // Pair represents a tuple of two values
 type Pair[A, B any] struct {
 	First  A
 	Second B
 }
 
+// Triple represents a tuple of three values
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
 // SliceIterator implements Iterator for slices
 type SliceIterator[T any] struct {
 	slice []T
@@ -42,6 +64,36 @@ func Iter[T any](slice []T) Iterator[T] {
 	return NewSliceIterator(slice)
 }
 
+// IterKeys creates an iterator over the keys of a map.
+// Map iteration order is non-deterministic, so the order of keys is unspecified.
+func IterKeys[K comparable, V any](m map[K]V) Iterator[K] {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return Iter(keys)
+}
+
+// IterValues creates an iterator over the values of a map.
+// Map iteration order is non-deterministic, so the order of values is unspecified.
+func IterValues[K comparable, V any](m map[K]V) Iterator[V] {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return Iter(values)
+}
+
+// IterEntries creates an iterator over the key-value pairs of a map.
+// Map iteration order is non-deterministic, so the order of entries is unspecified.
+func IterEntries[K comparable, V any](m map[K]V) Iterator[Pair[K, V]] {
+	entries := make([]Pair[K, V], 0, len(m))
+	for k, v := range m {
+		entries = append(entries, Pair[K, V]{First: k, Second: v})
+	}
+	return Iter(entries)
+}
+
 // MapIterator applies a function to each element
 type MapIterator[T any, U any] struct {
 	source Iterator[T]
@@ -147,6 +199,63 @@ func (it *SkipIterator[T]) Next() Option[T] {
 	return it.source.Next()
 }
 
+// TakeWhileIterator yields elements while a predicate holds
+type TakeWhileIterator[T any] struct {
+	source    Iterator[T]
+	predicate func(T) bool
+	done      bool
+}
+
+// TakeWhile creates an iterator that yields elements until the predicate first fails
+func TakeWhile[T any](source Iterator[T], predicate func(T) bool) Iterator[T] {
+	return &TakeWhileIterator[T]{
+		source:    source,
+		predicate: predicate,
+		done:      false,
+	}
+}
+
+func (it *TakeWhileIterator[T]) Next() Option[T] {
+	if it.done {
+		return None[T]()
+	}
+	next := it.source.Next()
+	if next.IsNone() || !it.predicate(next.Unwrap()) {
+		it.done = true
+		return None[T]()
+	}
+	return next
+}
+
+// SkipWhileIterator skips leading elements while a predicate holds
+type SkipWhileIterator[T any] struct {
+	source    Iterator[T]
+	predicate func(T) bool
+	skipped   bool
+}
+
+// SkipWhile creates an iterator that skips elements while the predicate holds, then yields the rest
+func SkipWhile[T any](source Iterator[T], predicate func(T) bool) Iterator[T] {
+	return &SkipWhileIterator[T]{
+		source:    source,
+		predicate: predicate,
+		skipped:   false,
+	}
+}
+
+func (it *SkipWhileIterator[T]) Next() Option[T] {
+	if !it.skipped {
+		it.skipped = true
+		for {
+			next := it.source.Next()
+			if next.IsNone() || !it.predicate(next.Unwrap()) {
+				return next
+			}
+		}
+	}
+	return it.source.Next()
+}
+
 // ChainIterator concatenates two iterators
 type ChainIterator[T any] struct {
 	first       Iterator[T]
@@ -174,6 +283,27 @@ func (it *ChainIterator[T]) Next() Option[T] {
 	return it.second.Next()
 }
 
+// ChainManyIterator concatenates any number of iterators
+type ChainManyIterator[T any] struct {
+	iters []Iterator[T]
+}
+
+// ChainMany concatenates any number of iterators, yielding from each in order
+func ChainMany[T any](iters ...Iterator[T]) Iterator[T] {
+	return &ChainManyIterator[T]{iters: iters}
+}
+
+func (it *ChainManyIterator[T]) Next() Option[T] {
+	for len(it.iters) > 0 {
+		next := it.iters[0].Next()
+		if next.IsSome() {
+			return next
+		}
+		it.iters = it.iters[1:]
+	}
+	return None[T]()
+}
+
 // ZipIterator zips two iterators together
 type ZipIterator[T any, U any] struct {
 	first  Iterator[T]
@@ -200,6 +330,42 @@ func (it *ZipIterator[T, U]) Next() Option[Pair[T, U]] {
 	return None[Pair[T, U]]()
 }
 
+// Zip3Iterator zips three iterators together
+type Zip3Iterator[A any, B any, C any] struct {
+	a Iterator[A]
+	b Iterator[B]
+	c Iterator[C]
+}
+
+// Zip3 'zips up' three iterators into a single iterator of triples, stopping when any source is exhausted
+func Zip3[A any, B any, C any](a Iterator[A], b Iterator[B], c Iterator[C]) Iterator[Triple[A, B, C]] {
+	return &Zip3Iterator[A, B, C]{
+		a: a,
+		b: b,
+		c: c,
+	}
+}
+
+func (it *Zip3Iterator[A, B, C]) Next() Option[Triple[A, B, C]] {
+	aNext := it.a.Next()
+	if aNext.IsNone() {
+		return None[Triple[A, B, C]]()
+	}
+	bNext := it.b.Next()
+	if bNext.IsNone() {
+		return None[Triple[A, B, C]]()
+	}
+	cNext := it.c.Next()
+	if cNext.IsNone() {
+		return None[Triple[A, B, C]]()
+	}
+	return Some(Triple[A, B, C]{
+		First:  aNext.Unwrap(),
+		Second: bNext.Unwrap(),
+		Third:  cNext.Unwrap(),
+	})
+}
+
 // EnumerateIterator adds indices to elements
 type EnumerateIterator[T any] struct {
 	source Iterator[T]
@@ -227,6 +393,226 @@ func (it *EnumerateIterator[T]) Next() Option[Pair[int, T]] {
 	return None[Pair[int, T]]()
 }
 
+// ScanIterator threads mutable state through the iteration
+type ScanIterator[T any, St any, U any] struct {
+	source Iterator[T]
+	state  St
+	f      func(*St, T) Option[U]
+	done   bool
+}
+
+// Scan creates an iterator that threads state through each element, stopping when f returns None
+func Scan[T any, St any, U any](source Iterator[T], init St, f func(*St, T) Option[U]) Iterator[U] {
+	return &ScanIterator[T, St, U]{
+		source: source,
+		state:  init,
+		f:      f,
+		done:   false,
+	}
+}
+
+func (it *ScanIterator[T, St, U]) Next() Option[U] {
+	if it.done {
+		return None[U]()
+	}
+	next := it.source.Next()
+	if next.IsNone() {
+		it.done = true
+		return None[U]()
+	}
+	result := it.f(&it.state, next.Unwrap())
+	if result.IsNone() {
+		it.done = true
+	}
+	return result
+}
+
+// FlatMapIterator maps each element to an iterator and flattens the result
+type FlatMapIterator[T any, U any] struct {
+	source  Iterator[T]
+	f       func(T) Iterator[U]
+	current Iterator[U]
+}
+
+// FlatMapIter creates an iterator that maps each element to an iterator and yields its elements lazily
+func FlatMapIter[T any, U any](source Iterator[T], f func(T) Iterator[U]) Iterator[U] {
+	return &FlatMapIterator[T, U]{
+		source:  source,
+		f:       f,
+		current: nil,
+	}
+}
+
+func (it *FlatMapIterator[T, U]) Next() Option[U] {
+	for {
+		if it.current != nil {
+			next := it.current.Next()
+			if next.IsSome() {
+				return next
+			}
+			it.current = nil
+		}
+		outer := it.source.Next()
+		if outer.IsNone() {
+			return None[U]()
+		}
+		it.current = it.f(outer.Unwrap())
+	}
+}
+
+// FlattenSlicesIterator yields the elements of each slice in turn
+type FlattenSlicesIterator[T any] struct {
+	source  Iterator[[]T]
+	current []T
+	index   int
+}
+
+// FlattenSlices creates an iterator that yields every element of every slice in order
+func FlattenSlices[T any](source Iterator[[]T]) Iterator[T] {
+	return &FlattenSlicesIterator[T]{
+		source: source,
+		index:  0,
+	}
+}
+
+func (it *FlattenSlicesIterator[T]) Next() Option[T] {
+	for it.index >= len(it.current) {
+		next := it.source.Next()
+		if next.IsNone() {
+			return None[T]()
+		}
+		it.current = next.Unwrap()
+		it.index = 0
+	}
+	value := it.current[it.index]
+	it.index++
+	return Some(value)
+}
+
+// DedupIterator removes consecutive duplicate elements
+type DedupIterator[T any] struct {
+	source Iterator[T]
+	eq     func(T, T) bool
+	last   Option[T]
+}
+
+// Dedup creates an iterator that removes consecutive duplicate elements
+func Dedup[T comparable](source Iterator[T]) Iterator[T] {
+	return DedupBy(source, func(a, b T) bool { return a == b })
+}
+
+// DedupBy creates an iterator that removes consecutive elements considered equal by eq
+func DedupBy[T any](source Iterator[T], eq func(T, T) bool) Iterator[T] {
+	return &DedupIterator[T]{
+		source: source,
+		eq:     eq,
+		last:   None[T](),
+	}
+}
+
+func (it *DedupIterator[T]) Next() Option[T] {
+	for {
+		next := it.source.Next()
+		if next.IsNone() {
+			return None[T]()
+		}
+		if it.last.IsSome() && it.eq(it.last.Unwrap(), next.Unwrap()) {
+			continue
+		}
+		it.last = next
+		return next
+	}
+}
+
+// IntersperseIterator inserts a separator between consecutive elements
+type IntersperseIterator[T any] struct {
+	source  Iterator[T]
+	sep     T
+	pending Option[T]
+	started bool
+}
+
+// Intersperse creates an iterator that places sep between each pair of consecutive elements
+func Intersperse[T any](source Iterator[T], sep T) Iterator[T] {
+	return &IntersperseIterator[T]{
+		source:  source,
+		sep:     sep,
+		pending: None[T](),
+		started: false,
+	}
+}
+
+func (it *IntersperseIterator[T]) Next() Option[T] {
+	if !it.started {
+		it.started = true
+		return it.source.Next()
+	}
+	if it.pending.IsSome() {
+		value := it.pending
+		it.pending = None[T]()
+		return value
+	}
+	next := it.source.Next()
+	if next.IsNone() {
+		return None[T]()
+	}
+	it.pending = next
+	return Some(it.sep)
+}
+
+// InspectIterator calls a function on each element as it passes through
+type InspectIterator[T any] struct {
+	source Iterator[T]
+	f      func(T)
+}
+
+// InspectIter creates an iterator that calls f on each element before passing it on unchanged
+func InspectIter[T any](source Iterator[T], f func(T)) Iterator[T] {
+	return &InspectIterator[T]{
+		source: source,
+		f:      f,
+	}
+}
+
+func (it *InspectIterator[T]) Next() Option[T] {
+	next := it.source.Next()
+	if next.IsSome() {
+		it.f(next.Unwrap())
+	}
+	return next
+}
+
+// RevIterator yields the elements of a buffered source in reverse order
+type RevIterator[T any] struct {
+	source   Iterator[T]
+	buffer   []T
+	buffered bool
+}
+
+// Rev creates an iterator that yields the elements of source in reverse.
+// Because Iterator is forward-only, the first call to Next drains the entire
+// source into a buffer, costing O(n) memory; the source must be finite.
+func Rev[T any](source Iterator[T]) Iterator[T] {
+	return &RevIterator[T]{
+		source:   source,
+		buffered: false,
+	}
+}
+
+func (it *RevIterator[T]) Next() Option[T] {
+	if !it.buffered {
+		it.buffer = Collect(it.source)
+		it.buffered = true
+	}
+	if len(it.buffer) == 0 {
+		return None[T]()
+	}
+	last := len(it.buffer) - 1
+	value := it.buffer[last]
+	it.buffer = it.buffer[:last]
+	return Some(value)
+}
+
 // Collect collects all elements from an iterator into a slice
 func Collect[T any](iter Iterator[T]) []T {
 	var result []T
@@ -240,6 +626,87 @@ func Collect[T any](iter Iterator[T]) []T {
 	return result
 }
 
+// TryCollect collects the Ok values of an iterator of Results into a slice,
+// stopping at and returning the first Err encountered
+func TryCollect[T any, E any](iter Iterator[Result[T, E]]) Result[[]T, E] {
+	var result []T
+	for {
+		next := iter.Next()
+		if next.IsNone() {
+			break
+		}
+		value := next.Unwrap()
+		if value.IsErr() {
+			return Err[[]T, E](value.UnwrapErr())
+		}
+		result = append(result, value.Unwrap())
+	}
+	return Ok[[]T, E](result)
+}
+
+// CollectSet collects all distinct elements from an iterator into a set
+func CollectSet[T comparable](iter Iterator[T]) map[T]struct{} {
+	result := make(map[T]struct{})
+	for {
+		next := iter.Next()
+		if next.IsNone() {
+			break
+		}
+		result[next.Unwrap()] = struct{}{}
+	}
+	return result
+}
+
+// GroupBy buckets elements by a computed key, preserving encounter order within each bucket
+func GroupBy[T any, K comparable](iter Iterator[T], key func(T) K) map[K][]T {
+	result := make(map[K][]T)
+	for {
+		next := iter.Next()
+		if next.IsNone() {
+			break
+		}
+		value := next.Unwrap()
+		k := key(value)
+		result[k] = append(result[k], value)
+	}
+	return result
+}
+
+// Tally counts how many times each distinct element appears
+func Tally[T comparable](iter Iterator[T]) map[T]int {
+	return CountBy(iter, func(x T) T { return x })
+}
+
+// CountBy counts elements by a computed key
+func CountBy[T any, K comparable](iter Iterator[T], key func(T) K) map[K]int {
+	result := make(map[K]int)
+	for {
+		next := iter.Next()
+		if next.IsNone() {
+			break
+		}
+		result[key(next.Unwrap())]++
+	}
+	return result
+}
+
+// Sorted collects all elements from an iterator into a sorted slice
+func Sorted[T cmp.Ordered](iter Iterator[T]) []T {
+	result := Collect(iter)
+	slices.Sort(result)
+	return result
+}
+
+// SortedBy collects all elements from an iterator into a slice sorted by less.
+// The sort is stable: equal elements keep their encounter order.
+func SortedBy[T any](iter Iterator[T], less func(T, T) bool) []T {
+	result := Collect(iter)
+	sort.SliceStable(result, func(i, j int) bool {
+		return less(result[i], result[j])
+	})
+	return result
+}
+
 // ForEach calls a function for each element in the iterator
 func ForEach[T any](iter Iterator[T], f func(T)) {
 	for {
@@ -251,6 +718,54 @@ func ForEach[T any](iter Iterator[T], f func(T)) {
 	}
 }
 
+// ToChannel streams the elements of an iterator into a channel with the given buffer size.
+// A goroutine drains the iterator and closes the channel when it is exhausted.
+// The goroutine blocks on send until every element is received, so a consumer
+// that stops reading early leaks it.
+func ToChannel[T any](iter Iterator[T], buffer int) <-chan T {
+	if buffer < 0 {
+		buffer = 0
+	}
+	ch := make(chan T, buffer)
+	go func() {
+		defer close(ch)
+		ForEach(iter, func(x T) {
+			ch <- x
+		})
+	}()
+	return ch
+}
+
+// ParMap applies f to every element of source across a pool of worker goroutines
+// and returns the results in the original order. If workers <= 0, runtime.NumCPU()
+// workers are used.
+func ParMap[T any, U any](source []T, workers int, f func(T) U) []U {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(source) {
+		workers = len(source)
+	}
+	result := make([]U, len(source))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				result[i] = f(source[i])
+			}
+		}()
+	}
+	for i := range source {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return result
+}
+
 // Fold folds every element into an accumulator
 func Fold[T any, U any](iter Iterator[T], initial U, f func(U, T) U) U {
 	acc := initial
@@ -264,6 +779,41 @@ func Fold[T any, U any](iter Iterator[T], initial U, f func(U, T) U) U {
 	return acc
 }
 
+// TryFold folds every element into an accumulator, stopping at the first Err
+func TryFold[T any, U any](iter Iterator[T], initial U, f func(U, T) Result[U, error]) Result[U, error] {
+	acc := initial
+	for {
+		next := iter.Next()
+		if next.IsNone() {
+			break
+		}
+		result := f(acc, next.Unwrap())
+		if result.IsErr() {
+			return result
+		}
+		acc = result.Unwrap()
+	}
+	return Ok[U, error](acc)
+}
+
+// FoldWhile folds elements into an accumulator until f returns false.
+// The accumulator returned alongside false is kept as the final result.
+func FoldWhile[T any, U any](iter Iterator[T], initial U, f func(U, T) (U, bool)) U {
+	acc := initial
+	for {
+		next := iter.Next()
+		if next.IsNone() {
+			break
+		}
+		var cont bool
+		acc, cont = f(acc, next.Unwrap())
+		if !cont {
+			break
+		}
+	}
+	return acc
+}
+
 // Reduce reduces the elements to a single value
 func Reduce[T any](iter Iterator[T], f func(T, T) T) Option[T] {
 	first := iter.Next()
@@ -326,6 +876,49 @@ func Find[T any](iter Iterator[T], predicate func(T) bool) Option[T] {
 	return None[T]()
 }
 
+// FindMap applies f to each element and returns the first Some result
+func FindMap[T any, U any](iter Iterator[T], f func(T) Option[U]) Option[U] {
+	for {
+		next := iter.Next()
+		if next.IsNone() {
+			break
+		}
+		if result := f(next.Unwrap()); result.IsSome() {
+			return result
+		}
+	}
+	return None[U]()
+}
+
+// Position returns the index of the first element that satisfies a predicate
+func Position[T any](iter Iterator[T], predicate func(T) bool) Option[int] {
+	index := 0
+	for {
+		next := iter.Next()
+		if next.IsNone() {
+			break
+		}
+		if predicate(next.Unwrap()) {
+			return Some(index)
+		}
+		index++
+	}
+	return None[int]()
+}
+
+// Nth returns the n-th (zero-based) element, consuming all elements up to it
+func Nth[T any](iter Iterator[T], n int) Option[T] {
+	if n < 0 {
+		return None[T]()
+	}
+	for i := 0; i < n; i++ {
+		if iter.Next().IsNone() {
+			return None[T]()
+		}
+	}
+	return iter.Next()
+}
+
 // Count counts the number of elements in the iterator
 func Count[T any](iter Iterator[T]) int {
 	count := 0
@@ -352,6 +945,101 @@ func Last[T any](iter Iterator[T]) Option[T] {
 	return last
 }
 
+// Max returns the maximum element of the iterator
+func Max[T cmp.Ordered](iter Iterator[T]) Option[T] {
+	return MaxBy(iter, cmp.Compare[T])
+}
+
+// Min returns the minimum element of the iterator
+func Min[T cmp.Ordered](iter Iterator[T]) Option[T] {
+	return MinBy(iter, cmp.Compare[T])
+}
+
+// MaxBy returns the maximum element with respect to the comparison function.
+// If several elements are equally maximum, the last one is returned.
+// Note that MaxByKey returns the first of equal elements instead.
+func MaxBy[T any](iter Iterator[T], compare func(T, T) int) Option[T] {
+	return Reduce(iter, func(acc, x T) T {
+		if compare(x, acc) >= 0 {
+			return x
+		}
+		return acc
+	})
+}
+
+// MinBy returns the minimum element with respect to the comparison function.
+// If several elements are equally minimum, the first one is returned.
+func MinBy[T any](iter Iterator[T], compare func(T, T) int) Option[T] {
+	return Reduce(iter, func(acc, x T) T {
+		if compare(x, acc) < 0 {
+			return x
+		}
+		return acc
+	})
+}
+
+// MaxByKey returns the element with the largest computed key.
+// If several elements share the largest key, the first one encountered is returned,
+// unlike MaxBy which returns the last of equal elements.
+func MaxByKey[T any, K cmp.Ordered](iter Iterator[T], key func(T) K) Option[T] {
+	return extremeByKey(iter, key, func(k, best K) bool { return k > best })
+}
+
+// MinByKey returns the element with the smallest computed key.
+// If several elements share the smallest key, the first one encountered is returned.
+func MinByKey[T any, K cmp.Ordered](iter Iterator[T], key func(T) K) Option[T] {
+	return extremeByKey(iter, key, func(k, best K) bool { return k < best })
+}
+
+// extremeByKey returns the first element whose key beats every other key, computing each key once
+func extremeByKey[T any, K cmp.Ordered](iter Iterator[T], key func(T) K, better func(K, K) bool) Option[T] {
+	first := iter.Next()
+	if first.IsNone() {
+		return None[T]()
+	}
+	best := first.Unwrap()
+	bestKey := key(best)
+	for {
+		next := iter.Next()
+		if next.IsNone() {
+			break
+		}
+		value := next.Unwrap()
+		if k := key(value); better(k, bestKey) {
+			best, bestKey = value, k
+		}
+	}
+	return Some(best)
+}
+
+// Sum returns the sum of all elements, or zero for an empty iterator
+func Sum[T Number](iter Iterator[T]) T {
+	return Fold(iter, T(0), func(acc, x T) T { return acc + x })
+}
+
+// Product returns the product of all elements, or one for an empty iterator
+func Product[T Number](iter Iterator[T]) T {
+	return Fold(iter, T(1), func(acc, x T) T { return acc * x })
+}
+
+// Average returns the arithmetic mean of the elements, or None for an empty iterator
+func Average[T Number](iter Iterator[T]) Option[float64] {
+	sum := 0.0
+	count := 0
+	for {
+		next := iter.Next()
+		if next.IsNone() {
+			break
+		}
+		sum += float64(next.Unwrap())
+		count++
+	}
+	if count == 0 {
+		return None[float64]()
+	}
+	return Some(sum / float64(count))
+}
+
 // Range creates an iterator over a range of integers
 func Range(start, end, step int) Iterator[int] {
 	return &RangeIterator{
@@ -410,6 +1098,99 @@ func (it *RepeatIterator[T]) Next() Option[T] {
 	return Some(it.value)
 }
 
+// Iterate creates an iterator yielding seed, f(seed), f(f(seed)), ... endlessly
+func Iterate[T any](seed T, f func(T) T) Iterator[T] {
+	return &IterateIterator[T]{
+		current: seed,
+		f:       f,
+	}
+}
+
+type IterateIterator[T any] struct {
+	current T
+	f       func(T) T
+}
+
+func (it *IterateIterator[T]) Next() Option[T] {
+	value := it.current
+	it.current = it.f(it.current)
+	return Some(value)
+}
+
+// Unfold creates an iterator from a state and a step function.
+// Each call to f yields an element and the next state, and None ends the iteration.
+func Unfold[St any, T any](init St, f func(St) Option[Pair[T, St]]) Iterator[T] {
+	return &UnfoldIterator[St, T]{
+		state: init,
+		f:     f,
+		done:  false,
+	}
+}
+
+type UnfoldIterator[St any, T any] struct {
+	state St
+	f     func(St) Option[Pair[T, St]]
+	done  bool
+}
+
+func (it *UnfoldIterator[St, T]) Next() Option[T] {
+	if it.done {
+		return None[T]()
+	}
+	step := it.f(it.state)
+	if step.IsNone() {
+		it.done = true
+		return None[T]()
+	}
+	pair := step.Unwrap()
+	it.state = pair.Second
+	return Some(pair.First)
+}
+
+// Cycle creates an iterator that repeats the elements of a slice endlessly.
+// The slice is copied, so later changes to it do not affect the iterator.
+// Cycling an empty slice yields nothing.
+func Cycle[T any](source []T) Iterator[T] {
+	snapshot := make([]T, len(source))
+	copy(snapshot, source)
+	return &CycleIterator[T]{
+		slice: snapshot,
+		index: 0,
+	}
+}
+
+type CycleIterator[T any] struct {
+	slice []T
+	index int
+}
+
+func (it *CycleIterator[T]) Next() Option[T] {
+	if len(it.slice) == 0 {
+		return None[T]()
+	}
+	value := it.slice[it.index]
+	it.index = (it.index + 1) % len(it.slice)
+	return Some(value)
+}
+
+// FromChannel creates an iterator that receives from a channel.
+// Next blocks until a value is available and returns None once the channel is closed.
+func FromChannel[T any](ch <-chan T) Iterator[T] {
+	return &ChannelIterator[T]{ch: ch}
+}
+
+type ChannelIterator[T any] struct {
+	ch <-chan T
+}
+
+func (it *ChannelIterator[T]) Next() Option[T] {
+	value, ok := <-it.ch
+	if !ok {
+		return None[T]()
+	}
+	return Some(value)
+}
+
 // Empty creates an empty iterator
 func Empty[T any]() Iterator[T] {
 	return &EmptyIterator[T]{}
@@ -3,8 +3,20 @@
 package trait
 
 import (
+	"cmp"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 // Trait is a marker interface for all traits
@@ -14,24 +26,50 @@ type Trait interface {
 }
 
 // TraitRegistry maintains a registry of trait implementations
+// and is safe for concurrent use
 type TraitRegistry struct {
+	mu              sync.RWMutex
 	implementations map[string]map[reflect.Type]interface{}
+	policy          DuplicatePolicy
 }
 
 var globalRegistry = &TraitRegistry{
 	implementations: make(map[string]map[reflect.Type]interface{}),
 }
 
-// Register registers a trait implementation for a specific type
-func Register[T Trait, Impl any](trait T, implementation Impl) {
-	traitName := trait.traitName()
-	typeKey := reflect.TypeOf((*Impl)(nil)).Elem()
+// DuplicatePolicy controls what happens when a trait is registered again for the same type
+type DuplicatePolicy int
 
-	if globalRegistry.implementations[traitName] == nil {
-		globalRegistry.implementations[traitName] = make(map[reflect.Type]interface{})
+const (
+	// OverwriteDuplicates replaces the existing implementation (the default)
+	OverwriteDuplicates DuplicatePolicy = iota
+	// RejectDuplicates keeps the existing implementation and reports an error
+	RejectDuplicates
+)
+
+// SetDuplicatePolicy sets the registry's duplicate-registration policy and returns the previous one
+func SetDuplicatePolicy(policy DuplicatePolicy) DuplicatePolicy {
+	globalRegistry.mu.Lock()
+	defer globalRegistry.mu.Unlock()
+	previous := globalRegistry.policy
+	globalRegistry.policy = policy
+	return previous
+}
+
+// Register registers a trait implementation for a specific type.
+// It panics if an implementation already exists and duplicates are rejected.
+func Register[T Trait, Impl any](trait T, implementation Impl) {
+	if err := TryRegister(trait, implementation); err != nil {
+		panic(err.Error())
 	}
+}
 
-	globalRegistry.implementations[traitName][typeKey] = implementation
+// TryRegister registers a trait implementation for a specific type,
+// returning an error if one already exists and duplicates are rejected
+func TryRegister[T Trait, Impl any](trait T, implementation Impl) error {
+	traitName := trait.traitName()
+	typeKey := reflect.TypeOf((*Impl)(nil)).Elem()
+	return register(traitName, typeKey, implementation)
 }
 
 // Get retrieves a trait implementation for a specific type
@@ -39,6 +77,8 @@ func Get[T Trait, Impl any](trait T) (Impl, bool) {
 	traitName := trait.traitName()
 	typeKey := reflect.TypeOf((*Impl)(nil)).Elem()
 
+	globalRegistry.mu.RLock()
+	defer globalRegistry.mu.RUnlock()
 	if impls, ok := globalRegistry.implementations[traitName]; ok {
 		if impl, ok := impls[typeKey]; ok {
 			return impl.(Impl), true
@@ -53,6 +93,7 @@ func Get[T Trait, Impl any](trait T) (Impl, bool) {
 type Implementor struct {
 	value      interface{}
 	traitImpls map[string]interface{}
+	missing    []string
 }
 
 // NewImplementor creates a new Implementor for the given value
@@ -80,41 +121,68 @@ func (i *Implementor) Value() interface{} {
 	return i.value
 }
 
-// TraitObject represents a type-erased trait object (dynamic dispatch)
+// Missing returns the required traits the value did not implement when the
+// implementor was created from a TraitComposition
+func (i *Implementor) Missing() []string {
+	return i.missing
+}
+
+// TraitObject represents a type-erased trait object (dynamic dispatch).
+// Methods are looked up in the vtable and resolved through reflection on
+// their first call, then cached, so methods added to the vtable after the
+// trait object is created are still found. Replacing a method that has
+// already been called requires a new trait object. Calls and methods set
+// through a TypedTraitObject may run concurrently; writing to the vtable
+// map directly must not overlap with calls.
 type TraitObject struct {
-	data   interface{}
-	vtable map[string]interface{}
+	data    interface{}
+	vtable  map[string]interface{}
+	mu      sync.RWMutex
+	methods map[string]dispatchResult
+}
+
+// dispatchResult is a resolved vtable method, or the error resolving it
+type dispatchResult struct {
+	entry *dispatchEntry
+	err   error
+}
+
+// dispatchEntry is a vtable method resolved once through reflection
+type dispatchEntry struct {
+	fn       reflect.Value
+	receiver reflect.Value
+	params   []reflect.Type // parameter types after the receiver; for variadic methods the last is the element type
+	variadic bool
 }
 
 // NewTraitObject creates a new trait object
 func NewTraitObject(data interface{}, vtable map[string]interface{}) *TraitObject {
 	return &TraitObject{
-		data:   data,
-		vtable: vtable,
+		data:    data,
+		vtable:  vtable,
+		methods: make(map[string]dispatchResult, len(vtable)),
 	}
 }
 
 // Call calls a method on the trait object
 func (to *TraitObject) Call(methodName string, args ...interface{}) ([]interface{}, error) {
-	method, ok := to.vtable[methodName]
+	resolved, ok := to.lookupMethod(methodName)
 	if !ok {
 		return nil, fmt.Errorf("method %s not found in vtable", methodName)
 	}
-
-	methodValue := reflect.ValueOf(method)
-	if methodValue.Kind() != reflect.Func {
-		return nil, fmt.Errorf("vtable entry for %s is not a function", methodName)
+	if resolved.err != nil {
+		return nil, resolved.err
 	}
+	entry := resolved.entry
 
-	// Prepare arguments
-	in := make([]reflect.Value, len(args)+1)
-	in[0] = reflect.ValueOf(to.data)
-	for i, arg := range args {
-		in[i+1] = reflect.ValueOf(arg)
+	// Prepare and validate arguments
+	in, err := entry.callArgs(methodName, args)
+	if err != nil {
+		return nil, err
 	}
 
 	// Call the method
-	results := methodValue.Call(in)
+	results := entry.fn.Call(in)
 
 	// Convert results to interface{}
 	out := make([]interface{}, len(results))
@@ -125,6 +193,179 @@ func (to *TraitObject) Call(methodName string, args ...interface{}) ([]interface
 	return out, nil
 }
 
+// lookupMethod returns the cached resolution of a method, resolving it from
+// the vtable and caching the result on a miss
+func (to *TraitObject) lookupMethod(methodName string) (dispatchResult, bool) {
+	to.mu.RLock()
+	resolved, ok := to.methods[methodName]
+	to.mu.RUnlock()
+	if ok {
+		return resolved, true
+	}
+
+	// Read the vtable and fill the cache under one lock so a concurrent
+	// setMethod cannot be overwritten by a stale resolution
+	to.mu.Lock()
+	defer to.mu.Unlock()
+	if resolved, ok := to.methods[methodName]; ok {
+		return resolved, true
+	}
+	method, ok := to.vtable[methodName]
+	if !ok {
+		return dispatchResult{}, false
+	}
+	entry, err := resolveMethod(methodName, method, to.data)
+	resolved = dispatchResult{entry: entry, err: err}
+	to.methods[methodName] = resolved
+	return resolved, true
+}
+
+// resolveMethod reflects on a vtable entry and checks that data can be its receiver
+func resolveMethod(methodName string, method interface{}, data interface{}) (*dispatchEntry, error) {
+	methodValue := reflect.ValueOf(method)
+	if methodValue.Kind() != reflect.Func {
+		return nil, fmt.Errorf("vtable entry for %s is not a function", methodName)
+	}
+
+	methodType := methodValue.Type()
+	if methodType.NumIn() == 0 {
+		return nil, fmt.Errorf("method %s does not accept a receiver", methodName)
+	}
+	receiver, err := argValue(data, methodType.In(0))
+	if err != nil {
+		return nil, fmt.Errorf("method %s receiver: %w", methodName, err)
+	}
+
+	entry := &dispatchEntry{
+		fn:       methodValue,
+		receiver: receiver,
+		params:   make([]reflect.Type, methodType.NumIn()-1),
+		variadic: methodType.IsVariadic(),
+	}
+	for i := range entry.params {
+		entry.params[i] = methodType.In(i + 1)
+	}
+	if entry.variadic {
+		last := len(entry.params) - 1
+		entry.params[last] = entry.params[last].Elem()
+	}
+	return entry, nil
+}
+
+// setMethod adds or replaces a vtable method and drops its cached resolution
+func (to *TraitObject) setMethod(methodName string, fn interface{}) {
+	to.mu.Lock()
+	defer to.mu.Unlock()
+	to.vtable[methodName] = fn
+	delete(to.methods, methodName)
+}
+
+// callArgs builds the reflect arguments for a call, checking the argument
+// count and types so that mismatches are reported as errors instead of
+// panicking inside reflect.
+func (e *dispatchEntry) callArgs(methodName string, args []interface{}) ([]reflect.Value, error) {
+	want := len(e.params)
+	if e.variadic {
+		if len(args) < want-1 {
+			return nil, fmt.Errorf("method %s expects at least %d args, got %d", methodName, want-1, len(args))
+		}
+	} else if len(args) != want {
+		return nil, fmt.Errorf("method %s expects %d args, got %d", methodName, want, len(args))
+	}
+
+	in := make([]reflect.Value, len(args)+1)
+	in[0] = e.receiver
+	for i, arg := range args {
+		paramType := e.params[min(i, want-1)]
+		value, err := argValue(arg, paramType)
+		if err != nil {
+			return nil, fmt.Errorf("method %s argument %d: %w", methodName, i+1, err)
+		}
+		in[i+1] = value
+	}
+	return in, nil
+}
+
+// argValue converts an argument to a reflect.Value assignable to paramType.
+// A nil argument becomes the zero value of nillable parameter types.
+func argValue(arg interface{}, paramType reflect.Type) (reflect.Value, error) {
+	if arg == nil {
+		switch paramType.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return reflect.Zero(paramType), nil
+		}
+		return reflect.Value{}, fmt.Errorf("cannot use nil as %v", paramType)
+	}
+	value := reflect.ValueOf(arg)
+	if !value.Type().AssignableTo(paramType) {
+		return reflect.Value{}, fmt.Errorf("cannot use %T as %v", arg, paramType)
+	}
+	return value, nil
+}
+
+// TypedTraitObject is a trait object whose receiver type is known at compile time.
+// Methods registered with Method and Method1 must accept T as their receiver,
+// so mismatched receivers are rejected by the compiler rather than at call time.
+type TypedTraitObject[T any] struct {
+	object *TraitObject
+	data   T
+}
+
+// NewTypedTraitObject creates a typed trait object with an empty vtable
+func NewTypedTraitObject[T any](data T) *TypedTraitObject[T] {
+	return &TypedTraitObject[T]{
+		object: NewTraitObject(data, make(map[string]interface{})),
+		data:   data,
+	}
+}
+
+// Method adds a method without arguments to the typed trait object
+func Method[T, R any](o *TypedTraitObject[T], methodName string, fn func(T) R) *TypedTraitObject[T] {
+	o.object.setMethod(methodName, fn)
+	return o
+}
+
+// Method1 adds a method with one argument to the typed trait object
+func Method1[T, A, R any](o *TypedTraitObject[T], methodName string, fn func(T, A) R) *TypedTraitObject[T] {
+	o.object.setMethod(methodName, fn)
+	return o
+}
+
+// Value returns the underlying value with its static type
+func (o *TypedTraitObject[T]) Value() T {
+	return o.data
+}
+
+// Object returns the type-erased trait object, e.g. for use with DynamicDispatch
+func (o *TypedTraitObject[T]) Object() *TraitObject {
+	return o.object
+}
+
+// Call calls a method on the typed trait object
+func (o *TypedTraitObject[T]) Call(methodName string, args ...interface{}) ([]interface{}, error) {
+	return o.object.Call(methodName, args...)
+}
+
+// CallTyped calls a single-result method and returns the result as R
+func CallTyped[T, R any](o *TypedTraitObject[T], methodName string, args ...interface{}) (R, error) {
+	var zero R
+	results, err := o.object.Call(methodName, args...)
+	if err != nil {
+		return zero, err
+	}
+	if len(results) != 1 {
+		return zero, fmt.Errorf("method %s returns %d values, expected 1", methodName, len(results))
+	}
+	if results[0] == nil {
+		return zero, nil
+	}
+	result, ok := results[0].(R)
+	if !ok {
+		return zero, fmt.Errorf("method %s returns %T, not %T", methodName, results[0], zero)
+	}
+	return result, nil
+}
+
 // Display is a trait for types that can be displayed as strings
 type Display interface {
 	Trait
@@ -325,9 +566,37 @@ func (t toStringTrait) ToString() string {
 // ToStringTrait is the singleton ToString trait
 var ToStringTrait ToString = toStringTrait{}
 
-// Derive is a helper for deriving traits automatically
+// Serialize is a trait for types that can be serialized
+type Serialize interface {
+	Trait
+	ToJSON() ([]byte, error)
+	ToMap() map[string]interface{}
+}
+
+// serializeTrait is the concrete trait type
+type serializeTrait struct{}
+
+func (s serializeTrait) traitName() string {
+	return "Serialize"
+}
+
+func (s serializeTrait) ToJSON() ([]byte, error) {
+	return []byte("{}"), nil
+}
+
+func (s serializeTrait) ToMap() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+// SerializeTrait is the singleton Serialize trait
+var SerializeTrait Serialize = serializeTrait{}
+
+// Derive is a helper for deriving traits automatically.
+// Derivations that cannot be produced for the target are not registered;
+// the reasons are collected and reported by Err.
 type Derive struct {
 	target interface{}
+	errs   []error
 }
 
 // NewDerive creates a new Derive helper for the target type
@@ -335,10 +604,42 @@ func NewDerive(target interface{}) *Derive {
 	return &Derive{target: target}
 }
 
+// Err returns the errors from derivations that could not be produced, or nil
+func (d *Derive) Err() error {
+	return errors.Join(d.errs...)
+}
+
+// register validates the target type with check and registers impl for it,
+// recording an error instead if either step fails
+func (d *Derive) register(traitName string, impl interface{}, check func(reflect.Type) error) {
+	targetType := reflect.TypeOf(d.target)
+	if targetType == nil {
+		d.errs = append(d.errs, fmt.Errorf("cannot derive %s for a nil target", traitName))
+		return
+	}
+	if check != nil {
+		if err := check(targetType); err != nil {
+			d.errs = append(d.errs, fmt.Errorf("cannot derive %s for %v: %w", traitName, targetType, err))
+			return
+		}
+	}
+	if err := register(traitName, targetType, impl); err != nil {
+		d.errs = append(d.errs, err)
+	}
+}
+
+// checkFormattable rejects types whose formatted value carries no information
+func checkFormattable(t reflect.Type) error {
+	switch t.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return fmt.Errorf("%v values have no meaningful text representation", t.Kind())
+	}
+	return nil
+}
+
 // Display derives the Display trait
 func (d *Derive) Display() *Derive {
 	// Auto-derive Display using reflection
-	targetType := reflect.TypeOf(d.target)
 	impl := struct {
 		DisplayFunc func() string
 	}{
@@ -347,17 +648,13 @@ func (d *Derive) Display() *Derive {
 		},
 	}
 	// Register with the target type as key
-	if globalRegistry.implementations["Display"] == nil {
-		globalRegistry.implementations["Display"] = make(map[reflect.Type]interface{})
-	}
-	globalRegistry.implementations["Display"][targetType] = impl
+	d.register("Display", impl, checkFormattable)
 	return d
 }
 
 // Debug derives the Debug trait
 func (d *Derive) Debug() *Derive {
 	// Auto-derive Debug using reflection
-	targetType := reflect.TypeOf(d.target)
 	impl := struct {
 		DebugFunc func() string
 	}{
@@ -366,60 +663,590 @@ func (d *Derive) Debug() *Derive {
 		},
 	}
 	// Register with the target type as key
-	if globalRegistry.implementations["Debug"] == nil {
-		globalRegistry.implementations["Debug"] = make(map[reflect.Type]interface{})
+	d.register("Debug", impl, checkFormattable)
+	return d
+}
+
+// DebugPretty derives the Debug trait with a multi-line representation that
+// lists each exported field on its own indented line, like Rust's {:#?}
+func (d *Derive) DebugPretty() *Derive {
+	impl := struct {
+		DebugFunc func() string
+	}{
+		DebugFunc: func() string {
+			var sb strings.Builder
+			writePretty(&sb, reflect.ValueOf(d.target), 0, make(map[visitKey]bool))
+			return sb.String()
+		},
 	}
-	globalRegistry.implementations["Debug"][targetType] = impl
+	// Register with the target type as key
+	d.register("Debug", impl, checkFormattable)
 	return d
 }
 
+// writePretty writes v to sb, indenting nested structs, collections and maps
+// by four spaces per level. Map entries are sorted by their formatted keys.
+// visited holds the pointers and maps on the current path; reaching one again
+// writes <cycle> instead of recursing forever.
+func writePretty(sb *strings.Builder, v reflect.Value, depth int, visited map[visitKey]bool) {
+	if !v.IsValid() {
+		sb.WriteString("nil")
+		return
+	}
+	indent := strings.Repeat("    ", depth+1)
+	closing := strings.Repeat("    ", depth)
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		if v.Kind() == reflect.Ptr {
+			sb.WriteString("&")
+			key := visitKey{typ: v.Type(), ptr: v.Pointer()}
+			if visited[key] {
+				sb.WriteString("<cycle>")
+				return
+			}
+			visited[key] = true
+			defer delete(visited, key)
+		}
+		writePretty(sb, v.Elem(), depth, visited)
+	case reflect.String:
+		sb.WriteString(strconv.Quote(v.String()))
+	case reflect.Struct:
+		sb.WriteString(v.Type().String())
+		sb.WriteString(" {")
+		wrote := false
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			sb.WriteString("\n" + indent + field.Name + ": ")
+			writePretty(sb, v.Field(i), depth+1, visited)
+			sb.WriteString(",")
+			wrote = true
+		}
+		if wrote {
+			sb.WriteString("\n" + closing)
+		}
+		sb.WriteString("}")
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		sb.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			sb.WriteString("\n" + indent)
+			writePretty(sb, v.Index(i), depth+1, visited)
+			sb.WriteString(",")
+		}
+		if v.Len() > 0 {
+			sb.WriteString("\n" + closing)
+		}
+		sb.WriteString("]")
+	case reflect.Map:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		key := visitKey{typ: v.Type(), ptr: v.Pointer()}
+		if visited[key] {
+			sb.WriteString("<cycle>")
+			return
+		}
+		visited[key] = true
+		defer delete(visited, key)
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		sb.WriteString("{")
+		for _, k := range keys {
+			sb.WriteString("\n" + indent)
+			writePretty(sb, k, depth+1, visited)
+			sb.WriteString(": ")
+			writePretty(sb, v.MapIndex(k), depth+1, visited)
+			sb.WriteString(",")
+		}
+		if len(keys) > 0 {
+			sb.WriteString("\n" + closing)
+		}
+		sb.WriteString("}")
+	default:
+		fmt.Fprintf(sb, "%v", v)
+	}
+}
+
 // Clone derives the Clone trait
 func (d *Derive) Clone() *Derive {
 	// Auto-derive Clone using reflection
-	targetType := reflect.TypeOf(d.target)
 	impl := struct {
 		CloneFunc func() interface{}
 	}{
 		CloneFunc: func() interface{} {
-			val := reflect.ValueOf(d.target)
-			if val.Kind() == reflect.Ptr {
-				// For pointers, create a new pointer to a copy of the value
-				elem := reflect.New(val.Elem().Type())
-				elem.Elem().Set(val.Elem())
-				return elem.Interface()
+			if d.target == nil {
+				return nil
 			}
-			// For values, return a copy
-			return reflect.New(val.Type()).Elem().Interface()
+			return deepCopy(reflect.ValueOf(d.target), make(map[visitKey]reflect.Value)).Interface()
 		},
 	}
 	// Register with the target type as key
-	if globalRegistry.implementations["Clone"] == nil {
-		globalRegistry.implementations["Clone"] = make(map[reflect.Type]interface{})
-	}
-	globalRegistry.implementations["Clone"][targetType] = impl
+	d.register("Clone", impl, nil)
 	return d
 }
 
+// visitKey identifies a pointer seen during a recursive walk. The type is part
+// of the key because a struct and its first field share an address.
+type visitKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// deepCopy recursively copies pointers, slices, maps, arrays, interfaces and
+// exported struct fields. Unexported struct fields cannot be set through
+// reflection and are copied shallowly. The visited map keeps shared and
+// cyclic pointers shared in the copy.
+func deepCopy(src reflect.Value, visited map[visitKey]reflect.Value) reflect.Value {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		key := visitKey{typ: src.Type(), ptr: src.Pointer()}
+		if dst, ok := visited[key]; ok {
+			return dst
+		}
+		dst := reflect.New(src.Type().Elem())
+		visited[key] = dst
+		dst.Elem().Set(deepCopy(src.Elem(), visited))
+		return dst
+	case reflect.Slice:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		dst := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(deepCopy(src.Index(i), visited))
+		}
+		return dst
+	case reflect.Array:
+		dst := reflect.New(src.Type()).Elem()
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(deepCopy(src.Index(i), visited))
+		}
+		return dst
+	case reflect.Map:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		dst := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(deepCopy(iter.Key(), visited), deepCopy(iter.Value(), visited))
+		}
+		return dst
+	case reflect.Interface:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(deepCopy(src.Elem(), visited))
+		return dst
+	case reflect.Struct:
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				dst.Field(i).Set(deepCopy(src.Field(i), visited))
+			}
+		}
+		return dst
+	default:
+		return src
+	}
+}
+
 // Eq derives the Eq trait
 func (d *Derive) Eq() *Derive {
 	// Auto-derive Eq using reflection
-	targetType := reflect.TypeOf(d.target)
 	impl := struct {
-		EqFunc func(other interface{}) bool
+		EqFunc func(a, b interface{}) bool
 	}{
-		EqFunc: func(other interface{}) bool {
-			return reflect.DeepEqual(d.target, other)
+		EqFunc: func(a, b interface{}) bool {
+			return reflect.DeepEqual(a, b)
 		},
 	}
 	// Register with the target type as key
-	if globalRegistry.implementations["Eq"] == nil {
-		globalRegistry.implementations["Eq"] = make(map[reflect.Type]interface{})
+	d.register("Eq", impl, nil)
+	return d
+}
+
+// Ord derives the Ord trait.
+// Values of different types never compare as equal, matching Eq; they are
+// ordered by type name with nil first, so sorting a mixed []interface{}
+// does not panic.
+func (d *Derive) Ord() *Derive {
+	// Auto-derive Ord by comparing exported fields in declaration order
+	impl := struct {
+		CmpFunc func(a, b interface{}) int
+	}{
+		CmpFunc: func(a, b interface{}) int {
+			at, bt := reflect.TypeOf(a), reflect.TypeOf(b)
+			if at != bt {
+				return compareTypes(at, bt)
+			}
+			if at == nil {
+				return 0
+			}
+			return compareValues(reflect.ValueOf(a), reflect.ValueOf(b), make(map[visitPair]bool))
+		},
+	}
+	d.register("Ord", impl, func(t reflect.Type) error {
+		return checkOrderable(t, make(map[reflect.Type]bool))
+	})
+	return d
+}
+
+// compareTypes orders two distinct types by name, then package path, with a
+// nil type first
+func compareTypes(a, b reflect.Type) int {
+	if a == nil || b == nil {
+		return cmp.Compare(boolToInt(a != nil), boolToInt(b != nil))
+	}
+	if c := cmp.Compare(a.String(), b.String()); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.PkgPath(), b.PkgPath())
+}
+
+// visitPair identifies a pair of pointers compared during a recursive walk
+type visitPair struct {
+	typ  reflect.Type
+	a, b uintptr
+}
+
+// compareValues compares two values of the same type, returning -1, 0 or 1.
+// Structs are compared field by field on their exported fields; kinds without
+// a natural order compare as equal. visited holds the pointer pairs on the
+// current path; meeting a pair again compares as equal, since any difference
+// in the cycle is found on the way around it.
+func compareValues(a, b reflect.Value, visited map[visitPair]bool) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case b.Bool():
+			return -1
+		default:
+			return 1
+		}
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return cmp.Compare(boolToInt(!a.IsNil()), boolToInt(!b.IsNil()))
+		}
+		pair := visitPair{typ: a.Type(), a: a.Pointer(), b: b.Pointer()}
+		if visited[pair] {
+			return 0
+		}
+		visited[pair] = true
+		defer delete(visited, pair)
+		return compareValues(a.Elem(), b.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !a.Type().Field(i).IsExported() {
+				continue
+			}
+			if c := compareValues(a.Field(i), b.Field(i), visited); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Slice, reflect.Array:
+		n := min(a.Len(), b.Len())
+		for i := 0; i < n; i++ {
+			if c := compareValues(a.Index(i), b.Index(i), visited); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.Len(), b.Len())
+	default:
+		return 0
+	}
+}
+
+// checkOrderable reports kinds that compareValues cannot order
+func checkOrderable(t reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+		return nil
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return checkOrderable(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if err := checkOrderable(field.Type, seen); err != nil {
+				return fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%v values have no natural order", t.Kind())
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Hash derives the Hash trait
+func (d *Derive) Hash() *Derive {
+	// Auto-derive Hash with FNV-1a over the target's fields
+	impl := struct {
+		HashFunc func() uint64
+	}{
+		HashFunc: func() uint64 {
+			h := fnv.New64a()
+			hashValue(h, reflect.ValueOf(d.target), make(map[visitKey]bool))
+			return h.Sum64()
+		},
+	}
+	d.register("Hash", impl, func(t reflect.Type) error {
+		return checkHashable(t, make(map[reflect.Type]bool))
+	})
+	return d
+}
+
+// hashValue feeds a value into h so that deeply equal values produce the
+// same bytes. Strings and collections are length-prefixed to keep adjacent
+// fields from running together, and map entries are combined
+// order-independently. visited holds the pointers and maps on the current
+// path; reaching one again hashes a back-reference marker instead of
+// recursing forever.
+func hashValue(h hash.Hash64, v reflect.Value, visited map[visitKey]bool) {
+	var buf [8]byte
+	writeUint := func(x uint64) {
+		binary.LittleEndian.PutUint64(buf[:], x)
+		h.Write(buf[:])
+	}
+
+	if !v.IsValid() {
+		h.Write([]byte{0})
+		return
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f == 0 {
+			f = 0 // -0 and +0 are equal, so they must hash alike
+		}
+		writeUint(math.Float64bits(f))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeUint(math.Float64bits(real(c)))
+		writeUint(math.Float64bits(imag(c)))
+	case reflect.Bool:
+		if v.Bool() {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+	case reflect.String:
+		writeUint(uint64(v.Len()))
+		h.Write([]byte(v.String()))
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			h.Write([]byte{0})
+			return
+		}
+		if v.Kind() == reflect.Interface {
+			h.Write([]byte{1})
+			h.Write([]byte(v.Elem().Type().String()))
+			hashValue(h, v.Elem(), visited)
+			return
+		}
+		key := visitKey{typ: v.Type(), ptr: v.Pointer()}
+		if visited[key] {
+			h.Write([]byte{2})
+			return
+		}
+		visited[key] = true
+		h.Write([]byte{1})
+		hashValue(h, v.Elem(), visited)
+		delete(visited, key)
+	case reflect.Slice, reflect.Array:
+		writeUint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i), visited)
+		}
+	case reflect.Map:
+		key := visitKey{typ: v.Type(), ptr: v.Pointer()}
+		if visited[key] {
+			h.Write([]byte{2})
+			return
+		}
+		visited[key] = true
+		var sum uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			entry := fnv.New64a()
+			hashValue(entry, iter.Key(), visited)
+			hashValue(entry, iter.Value(), visited)
+			sum += entry.Sum64()
+		}
+		delete(visited, key)
+		writeUint(uint64(v.Len()))
+		writeUint(sum)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			hashValue(h, v.Field(i), visited)
+		}
+	default:
+		// Funcs, channels and unsafe pointers have no meaningful content to hash
+		h.Write([]byte(v.Type().String()))
 	}
-	globalRegistry.implementations["Eq"][targetType] = impl
+}
+
+// Serialize derives the Serialize trait
+func (d *Derive) Serialize() *Derive {
+	// Auto-derive Serialize from exported fields, honoring json tags
+	impl := struct {
+		ToJSONFunc func() ([]byte, error)
+		ToMapFunc  func() map[string]interface{}
+	}{
+		ToJSONFunc: func() ([]byte, error) {
+			return json.Marshal(d.target)
+		},
+		ToMapFunc: func() map[string]interface{} {
+			result := make(map[string]interface{})
+			val := reflect.ValueOf(d.target)
+			for val.Kind() == reflect.Ptr && !val.IsNil() {
+				val = val.Elem()
+			}
+			if val.Kind() == reflect.Struct {
+				structToMap(val, result)
+			}
+			return result
+		},
+	}
+	d.register("Serialize", impl, func(reflect.Type) error {
+		_, err := json.Marshal(d.target)
+		return err
+	})
 	return d
 }
 
-// Default derives the Default trait
+// structToMap copies the exported fields of a struct into m using the same
+// keys encoding/json would: the json tag name when present, fields tagged
+// "-" skipped, omitempty honored and untagged embedded structs flattened.
+func structToMap(val reflect.Value, m map[string]interface{}) {
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fieldVal := val.Field(i)
+
+		if field.Anonymous && name == "" {
+			embedded := fieldVal
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				structToMap(embedded, m)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(opts, "omitempty") && isEmptyValue(fieldVal) {
+			continue
+		}
+		m[name] = fieldVal.Interface()
+	}
+}
+
+// isEmptyValue reports whether v is empty in the encoding/json omitempty sense
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	case reflect.Struct:
+		return false
+	default:
+		return v.IsZero()
+	}
+}
+
+// checkHashable reports kinds that hashValue cannot hash by content
+func checkHashable(t reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return fmt.Errorf("%v values cannot be hashed by content", t.Kind())
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return checkHashable(t.Elem(), seen)
+	case reflect.Map:
+		if err := checkHashable(t.Key(), seen); err != nil {
+			return err
+		}
+		return checkHashable(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if err := checkHashable(t.Field(i).Type, seen); err != nil {
+				return fmt.Errorf("field %s: %w", t.Field(i).Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Default derives the Default trait.
+// Struct fields tagged with `default:"..."` are set to the parsed tag value;
+// untagged fields keep their zero value. A tag that cannot be parsed into its
+// field's type is reported by Err.
 func (d *Derive) Default() *Derive {
 	// Auto-derive Default using reflection
 	targetType := reflect.TypeOf(d.target)
@@ -427,21 +1254,151 @@ func (d *Derive) Default() *Derive {
 		DefaultFunc func() interface{}
 	}{
 		DefaultFunc: func() interface{} {
-			t := reflect.TypeOf(d.target)
-			return reflect.New(t).Elem().Interface()
+			if targetType == nil {
+				return nil
+			}
+			v, _ := defaultValue(targetType)
+			return v.Interface()
 		},
 	}
-	// Register with the target type as key
-	if globalRegistry.implementations["Default"] == nil {
-		globalRegistry.implementations["Default"] = make(map[reflect.Type]interface{})
+	// Register with the target type as key, validating the tags up front
+	d.register("Default", impl, func(t reflect.Type) error {
+		_, err := defaultValue(t)
+		return err
+	})
+	return d
+}
+
+// defaultValue builds a value of type t with struct tag defaults applied.
+// Pointers to structs are allocated and nested structs are filled recursively.
+func defaultValue(t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Ptr:
+		if t.Elem().Kind() == reflect.Struct {
+			elem, err := defaultValue(t.Elem())
+			if err != nil {
+				return v, err
+			}
+			v = reflect.New(t.Elem())
+			v.Elem().Set(elem)
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			tag, ok := field.Tag.Lookup("default")
+			if !ok {
+				if field.Type.Kind() == reflect.Struct {
+					nested, err := defaultValue(field.Type)
+					if err != nil {
+						return v, err
+					}
+					v.Field(i).Set(nested)
+				}
+				continue
+			}
+			if err := setFromString(v.Field(i), tag); err != nil {
+				return v, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+	}
+	return v, nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setFromString parses s into v according to v's kind
+func setFromString(v reflect.Value, s string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported kind %v", v.Kind())
+	}
+	return nil
+}
+
+// FromStr derives the FromStr trait.
+// Numeric, bool and string targets are parsed with strconv; structs, maps,
+// slices and pointers are decoded from JSON.
+func (d *Derive) FromStr() *Derive {
+	targetType := reflect.TypeOf(d.target)
+	impl := struct {
+		FromStrFunc func(s string) (interface{}, error)
+	}{
+		FromStrFunc: func(s string) (interface{}, error) {
+			if targetType == nil {
+				return nil, fmt.Errorf("cannot parse %q into a nil target", s)
+			}
+			v := reflect.New(targetType)
+			switch targetType.Kind() {
+			case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Ptr:
+				if err := json.Unmarshal([]byte(s), v.Interface()); err != nil {
+					return nil, fmt.Errorf("cannot parse %q as %v: %w", s, targetType, err)
+				}
+			default:
+				if err := setFromString(v.Elem(), s); err != nil {
+					return nil, fmt.Errorf("cannot parse %q as %v: %w", s, targetType, err)
+				}
+			}
+			return v.Elem().Interface(), nil
+		},
 	}
-	globalRegistry.implementations["Default"][targetType] = impl
+	d.register("FromStr", impl, checkParsable)
 	return d
 }
 
+// checkParsable reports target types FromStr cannot produce
+func checkParsable(t reflect.Type) error {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Ptr,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+		return nil
+	}
+	return fmt.Errorf("%v values cannot be parsed from a string", t.Kind())
+}
+
 // TraitComposition allows composing multiple traits
 type TraitComposition struct {
-	traits []string
+	traits   []string
+	requires []string
 }
 
 // Compose creates a new trait composition
@@ -449,23 +1406,44 @@ func Compose(traits ...string) *TraitComposition {
 	return &TraitComposition{traits: traits}
 }
 
-// Implement creates an implementor with all composed traits
+// Implement creates an implementor with all composed and required traits.
+// Required traits the value does not implement are recorded on the
+// implementor and reported by Missing; use TryImplement to fail instead.
 func (tc *TraitComposition) Implement(value interface{}) *Implementor {
 	impl := NewImplementor(value)
 	for _, trait := range tc.traits {
 		// Look up trait implementation in registry
-		if impls, ok := globalRegistry.implementations[trait]; ok {
-			for typeKey, traitImpl := range impls {
-				if reflect.TypeOf(value).AssignableTo(typeKey) {
-					impl.With(trait, traitImpl)
-					break
-				}
-			}
+		if traitImpl, ok := Lookup(trait, value); ok {
+			impl.With(trait, traitImpl)
+		}
+	}
+	for _, trait := range tc.requires {
+		if traitImpl, ok := Lookup(trait, value); ok {
+			impl.With(trait, traitImpl)
+		} else {
+			impl.missing = append(impl.missing, trait)
 		}
 	}
 	return impl
 }
 
+// Requires declares supertraits that a value must implement before the
+// composition can be implemented for it
+func (tc *TraitComposition) Requires(traits ...string) *TraitComposition {
+	tc.requires = append(tc.requires, traits...)
+	return tc
+}
+
+// TryImplement creates an implementor with all composed and required traits,
+// or returns an error listing the required traits the value does not implement
+func (tc *TraitComposition) TryImplement(value interface{}) (*Implementor, error) {
+	impl := tc.Implement(value)
+	if len(impl.missing) > 0 {
+		return nil, fmt.Errorf("value of type %T is missing required traits: %s", value, strings.Join(impl.missing, ", "))
+	}
+	return impl, nil
+}
+
 // TraitBound represents a trait bound for generic constraints
 type TraitBound struct {
 	traitName string
@@ -478,15 +1456,7 @@ func NewBound(traitName string) *TraitBound {
 
 // Check checks if a value satisfies the trait bound
 func (tb *TraitBound) Check(value interface{}) bool {
-	if impls, ok := globalRegistry.implementations[tb.traitName]; ok {
-		valueType := reflect.TypeOf(value)
-		for typeKey := range impls {
-			if valueType.AssignableTo(typeKey) {
-				return true
-			}
-		}
-	}
-	return false
+	return HasTrait(tb.traitName, value)
 }
 
 // Require panics if the value doesn't satisfy the trait bound
@@ -522,28 +1492,114 @@ func (dd *DynamicDispatch) Call(name, method string, args ...interface{}) ([]int
 	return obj.Call(method, args...)
 }
 
-// TraitAlias creates an alias for a trait
+// TraitAlias creates an alias for a trait.
+// The alias receives a copy of the implementations registered for the
+// original at the time of the call, so registering or removing
+// implementations under one name never affects the other.
 func TraitAlias(original, alias string) {
+	globalRegistry.mu.Lock()
+	defer globalRegistry.mu.Unlock()
 	if impls, ok := globalRegistry.implementations[original]; ok {
-		globalRegistry.implementations[alias] = impls
+		copied := make(map[reflect.Type]interface{}, len(impls))
+		for typeKey, impl := range impls {
+			copied[typeKey] = impl
+		}
+		globalRegistry.implementations[alias] = copied
 	}
 }
 
 // HasTrait checks if a type has a specific trait implementation
 func HasTrait(traitName string, value interface{}) bool {
-	if impls, ok := globalRegistry.implementations[traitName]; ok {
-		valueType := reflect.TypeOf(value)
-		for typeKey := range impls {
-			if valueType.AssignableTo(typeKey) {
-				return true
-			}
+	_, ok := Lookup(traitName, value)
+	return ok
+}
+
+// HasTraits checks if a type has implementations for all of the named traits
+func HasTraits(value interface{}, traitNames ...string) bool {
+	return len(MissingTraits(value, traitNames...)) == 0
+}
+
+// MissingTraits returns the named traits the value's type does not implement,
+// in the order they were given
+func MissingTraits(value interface{}, traitNames ...string) []string {
+	var missing []string
+	for _, name := range traitNames {
+		if !HasTrait(name, value) {
+			missing = append(missing, name)
 		}
 	}
-	return false
+	return missing
+}
+
+// register stores an implementation for a trait under the given type,
+// honoring the registry's duplicate policy
+func register(traitName string, typeKey reflect.Type, impl interface{}) error {
+	globalRegistry.mu.Lock()
+	defer globalRegistry.mu.Unlock()
+	if globalRegistry.implementations[traitName] == nil {
+		globalRegistry.implementations[traitName] = make(map[reflect.Type]interface{})
+	}
+	if _, exists := globalRegistry.implementations[traitName][typeKey]; exists && globalRegistry.policy == RejectDuplicates {
+		return fmt.Errorf("trait %s is already registered for type %v", traitName, typeKey)
+	}
+	globalRegistry.implementations[traitName][typeKey] = impl
+	return nil
+}
+
+// mustRegister is like register but panics if the registration is rejected
+func mustRegister(traitName string, typeKey reflect.Type, impl interface{}) {
+	if err := register(traitName, typeKey, impl); err != nil {
+		panic(err.Error())
+	}
+}
+
+// RemoveTrait removes the implementation of a trait registered for the value's type.
+// It reports whether an implementation was removed.
+func RemoveTrait(traitName string, value interface{}) bool {
+	globalRegistry.mu.Lock()
+	defer globalRegistry.mu.Unlock()
+	impls, ok := globalRegistry.implementations[traitName]
+	if !ok {
+		return false
+	}
+	typeKey := reflect.TypeOf(value)
+	if _, ok := impls[typeKey]; !ok {
+		return false
+	}
+	delete(impls, typeKey)
+	if len(impls) == 0 {
+		delete(globalRegistry.implementations, traitName)
+	}
+	return true
+}
+
+// Lookup retrieves the trait implementation registered for the value's type
+func Lookup(traitName string, value interface{}) (interface{}, bool) {
+	globalRegistry.mu.RLock()
+	defer globalRegistry.mu.RUnlock()
+	impls, ok := globalRegistry.implementations[traitName]
+	if !ok {
+		return nil, false
+	}
+	valueType := reflect.TypeOf(value)
+	if valueType == nil {
+		return nil, false
+	}
+	if impl, ok := impls[valueType]; ok {
+		return impl, true
+	}
+	for typeKey, impl := range impls {
+		if valueType.AssignableTo(typeKey) {
+			return impl, true
+		}
+	}
+	return nil, false
 }
 
 // GetTraitNames returns all registered trait names
 func GetTraitNames() []string {
+	globalRegistry.mu.RLock()
+	defer globalRegistry.mu.RUnlock()
 	names := make([]string, 0, len(globalRegistry.implementations))
 	for name := range globalRegistry.implementations {
 		names = append(names, name)
@@ -553,6 +1609,8 @@ func GetTraitNames() []string {
 
 // ClearRegistry clears the trait registry (mainly for testing)
 func ClearRegistry() {
+	globalRegistry.mu.Lock()
+	defer globalRegistry.mu.Unlock()
 	globalRegistry.implementations = make(map[string]map[reflect.Type]interface{})
 }
 
@@ -561,53 +1619,43 @@ func ClearRegistry() {
 func init() {
 	// Register Display for int
 	intType := reflect.TypeOf(0)
-	if globalRegistry.implementations["Display"] == nil {
-		globalRegistry.implementations["Display"] = make(map[reflect.Type]interface{})
-	}
-	globalRegistry.implementations["Display"][intType] = struct {
+	mustRegister("Display", intType, struct {
 		DisplayFunc func() string
 	}{
 		DisplayFunc: func() string {
 			return "int"
 		},
-	}
+	})
 
 	// Register Display for string
 	stringType := reflect.TypeOf("")
-	globalRegistry.implementations["Display"][stringType] = struct {
+	mustRegister("Display", stringType, struct {
 		DisplayFunc func() string
 	}{
 		DisplayFunc: func() string {
 			return "string"
 		},
-	}
+	})
 
 	// Register Eq for int
-	if globalRegistry.implementations["Eq"] == nil {
-		globalRegistry.implementations["Eq"] = make(map[reflect.Type]interface{})
-	}
-	globalRegistry.implementations["Eq"][intType] = struct {
-		EqFunc func(other interface{}) bool
+	mustRegister("Eq", intType, struct {
+		EqFunc func(a, b interface{}) bool
 	}{
-		EqFunc: func(other interface{}) bool {
-			if _, ok := other.(int); ok {
-				return true // Simplified for example
-			}
-			return false
+		EqFunc: func(a, b interface{}) bool {
+			x, ok := a.(int)
+			y, ok2 := b.(int)
+			return ok && ok2 && x == y
 		},
-	}
+	})
 
 	// Register Clone for int
-	if globalRegistry.implementations["Clone"] == nil {
-		globalRegistry.implementations["Clone"] = make(map[reflect.Type]interface{})
-	}
-	globalRegistry.implementations["Clone"][intType] = struct {
+	mustRegister("Clone", intType, struct {
 		CloneFunc func() interface{}
 	}{
 		CloneFunc: func() interface{} {
 			return 0
 		},
-	}
+	})
 
 	// Debug: Print registered trait names
 	// fmt.Println("Registered traits in init():")
@@ -2,8 +2,13 @@
 package trait_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/dongrv/rust-go/trait"
 )
@@ -18,6 +23,33 @@ type Point struct {
 	X, Y int
 }
 
+type Team struct {
+	Name    string
+	Members []string
+	Scores  map[string]int
+	Lead    *Person
+	Origin  Point
+}
+
+// Node is a linked list node; a node whose Next points back at itself makes
+// a cyclic value.
+type Node struct {
+	Value int
+	Next  *Node
+}
+
+// cycle builds a ring of nodes holding the given values
+func cycle(values ...int) *Node {
+	head := &Node{Value: values[0]}
+	tail := head
+	for _, v := range values[1:] {
+		tail.Next = &Node{Value: v}
+		tail = tail.Next
+	}
+	tail.Next = head
+	return head
+}
+
 func TestTraitRegistration(t *testing.T) {
 	// Clear registry before test
 	trait.ClearRegistry()
@@ -130,6 +162,201 @@ func TestTraitObject(t *testing.T) {
 	}
 }
 
+func TestTraitObjectCallValidation(t *testing.T) {
+	person := Person{Name: "Eve", Age: 28}
+
+	vtable := map[string]interface{}{
+		"Greet": func(p Person, greeting string) string {
+			return greeting + ", " + p.Name
+		},
+		"AddYears": func(p Person, years ...int) int {
+			total := p.Age
+			for _, y := range years {
+				total += y
+			}
+			return total
+		},
+		"Describe": func(p Person, tags []string) int {
+			return len(tags)
+		},
+		"PointX": func(p Point) int {
+			return p.X
+		},
+	}
+	obj := trait.NewTraitObject(person, vtable)
+
+	// Test wrong arity
+	_, err := obj.Call("Greet")
+	if err == nil || err.Error() != "method Greet expects 1 args, got 0" {
+		t.Errorf("Expected arity error, got %v", err)
+	}
+	_, err = obj.Call("Greet", "Hi", "extra")
+	if err == nil {
+		t.Error("Expected an error for too many args")
+	}
+
+	// Test wrong argument type
+	_, err = obj.Call("Greet", 42)
+	if err == nil || err.Error() != "method Greet argument 1: cannot use int as string" {
+		t.Errorf("Expected type error, got %v", err)
+	}
+
+	// Test wrong receiver type
+	_, err = obj.Call("PointX")
+	if err == nil {
+		t.Error("Expected an error for a mismatched receiver")
+	}
+
+	// Test nil for a nillable parameter
+	results, err := obj.Call("Describe", nil)
+	if err != nil || results[0].(int) != 0 {
+		t.Errorf("Expected nil slice argument to be accepted, got %v, %v", results, err)
+	}
+
+	// Test variadic methods
+	results, err = obj.Call("AddYears")
+	if err != nil || results[0].(int) != 28 {
+		t.Errorf("Expected 28 with no variadic args, got %v, %v", results, err)
+	}
+	results, err = obj.Call("AddYears", 1, 2, 3)
+	if err != nil || results[0].(int) != 34 {
+		t.Errorf("Expected 34 with variadic args, got %v, %v", results, err)
+	}
+	_, err = obj.Call("AddYears", 1, "two")
+	if err == nil || err.Error() != "method AddYears argument 2: cannot use string as int" {
+		t.Errorf("Expected variadic type error, got %v", err)
+	}
+
+	// Test a valid call still works
+	results, err = obj.Call("Greet", "Hello")
+	if err != nil || results[0].(string) != "Hello, Eve" {
+		t.Errorf("Expected 'Hello, Eve', got %v, %v", results, err)
+	}
+}
+
+func TestTypedTraitObject(t *testing.T) {
+	person := Person{Name: "Frank", Age: 50}
+
+	obj := trait.NewTypedTraitObject(person)
+	trait.Method(obj, "GetName", func(p Person) string { return p.Name })
+	trait.Method1(obj, "OlderBy", func(p Person, years int) int { return p.Age + years })
+
+	// Test typed calls
+	name, err := trait.CallTyped[Person, string](obj, "GetName")
+	if err != nil || name != "Frank" {
+		t.Errorf("Expected 'Frank', got %q, %v", name, err)
+	}
+	age, err := trait.CallTyped[Person, int](obj, "OlderBy", 5)
+	if err != nil || age != 55 {
+		t.Errorf("Expected 55, got %d, %v", age, err)
+	}
+
+	// Test the receiver keeps its static type
+	if obj.Value().Age != 50 {
+		t.Errorf("Expected Value to return the typed receiver, got %v", obj.Value())
+	}
+
+	// Test result type mismatch
+	if _, err := trait.CallTyped[Person, int](obj, "GetName"); err == nil {
+		t.Error("Expected an error when the result type does not match")
+	}
+
+	// Test argument validation still applies
+	if _, err := trait.CallTyped[Person, int](obj, "OlderBy", "five"); err == nil {
+		t.Error("Expected an error for a wrong argument type")
+	}
+
+	// Test interop with DynamicDispatch
+	dd := trait.NewDynamicDispatch()
+	dd.Add("frank", obj.Object())
+	results, err := dd.Call("frank", "GetName")
+	if err != nil || results[0].(string) != "Frank" {
+		t.Errorf("Expected 'Frank' through DynamicDispatch, got %v, %v", results, err)
+	}
+}
+
+func TestTraitObjectDispatchCache(t *testing.T) {
+	vtable := map[string]interface{}{
+		"OlderBy": func(p Person, years int) int { return p.Age + years },
+		"GetName": func(p Person) string { return p.Name },
+	}
+	alice := trait.NewTraitObject(Person{Name: "Alice", Age: 30}, vtable)
+	bob := trait.NewTraitObject(Person{Name: "Bob", Age: 60}, vtable)
+
+	// Test repeated calls with different arguments
+	for years := 0; years < 100; years++ {
+		results, err := alice.Call("OlderBy", years)
+		if err != nil || results[0].(int) != 30+years {
+			t.Fatalf("Expected %d, got %v, %v", 30+years, results, err)
+		}
+	}
+
+	// Test objects sharing a vtable keep their own receivers
+	for i := 0; i < 3; i++ {
+		a, _ := alice.Call("GetName")
+		b, _ := bob.Call("GetName")
+		if a[0].(string) != "Alice" || b[0].(string) != "Bob" {
+			t.Fatalf("Expected Alice and Bob, got %v and %v", a[0], b[0])
+		}
+	}
+
+	// Test errors are reported consistently on repeated calls
+	for i := 0; i < 2; i++ {
+		if _, err := alice.Call("OlderBy", "ten"); err == nil {
+			t.Error("Expected an argument error on every call")
+		}
+	}
+
+	// Test replacing a method on a typed trait object
+	typed := trait.NewTypedTraitObject(Person{Name: "Carol", Age: 20})
+	trait.Method(typed, "GetName", func(p Person) string { return p.Name })
+	first, _ := trait.CallTyped[Person, string](typed, "GetName")
+	trait.Method(typed, "GetName", func(p Person) string { return "Dr. " + p.Name })
+	second, _ := trait.CallTyped[Person, string](typed, "GetName")
+	if first != "Carol" || second != "Dr. Carol" {
+		t.Errorf("Expected the replaced method to be used, got %q then %q", first, second)
+	}
+
+	// Test methods added to the vtable after construction are found
+	if _, err := alice.Call("Greet"); err == nil {
+		t.Error("Expected an error for a method not yet in the vtable")
+	}
+	vtable["Greet"] = func(p Person) string { return "Hello, " + p.Name }
+	greeting, err := alice.Call("Greet")
+	if err != nil || greeting[0].(string) != "Hello, Alice" {
+		t.Errorf("Expected Hello, Alice, got %v, %v", greeting, err)
+	}
+}
+
+func TestTraitObjectConcurrentMethods(t *testing.T) {
+	typed := trait.NewTypedTraitObject(Person{Name: "Dana", Age: 40})
+	trait.Method(typed, "GetName", func(p Person) string { return p.Name })
+
+	// Test calls racing with method replacement see one of the methods
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			trait.Method(typed, "GetName", func(p Person) string { return "Dr. " + p.Name })
+		}()
+		go func() {
+			defer wg.Done()
+			name, err := trait.CallTyped[Person, string](typed, "GetName")
+			if err != nil || (name != "Dana" && name != "Dr. Dana") {
+				t.Errorf("Expected Dana or Dr. Dana, got %q, %v", name, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Test the last replacement is not shadowed by a stale cached method
+	trait.Method(typed, "GetName", func(p Person) string { return "Prof. " + p.Name })
+	if name, _ := trait.CallTyped[Person, string](typed, "GetName"); name != "Prof. Dana" {
+		t.Errorf("Expected Prof. Dana, got %q", name)
+	}
+}
+
 func TestDerive(t *testing.T) {
 	trait.ClearRegistry()
 
@@ -171,6 +398,582 @@ func TestDerive(t *testing.T) {
 	}
 }
 
+func TestDeriveDebugPretty(t *testing.T) {
+	trait.ClearRegistry()
+
+	team := Team{
+		Name:    "core",
+		Members: []string{"alice", "bob"},
+		Scores:  map[string]int{"bob": 2, "alice": 1},
+		Lead:    &Person{Name: "alice", Age: 30},
+		Origin:  Point{X: 1, Y: 2},
+	}
+	if err := trait.NewDerive(team).DebugPretty().Err(); err != nil {
+		t.Fatalf("DebugPretty should succeed: %v", err)
+	}
+	impl, found := trait.Lookup("Debug", team)
+	if !found {
+		t.Fatal("Debug trait should be found for Team")
+	}
+	output := impl.(struct {
+		DebugFunc func() string
+	}).DebugFunc()
+
+	expected := `trait_test.Team {
+    Name: "core",
+    Members: [
+        "alice",
+        "bob",
+    ],
+    Scores: {
+        "alice": 1,
+        "bob": 2,
+    },
+    Lead: &trait_test.Person {
+        Name: "alice",
+        Age: 30,
+    },
+    Origin: trait_test.Point {
+        X: 1,
+        Y: 2,
+    },
+}`
+	if output != expected {
+		t.Errorf("Unexpected pretty output:\n%s\nexpected:\n%s", output, expected)
+	}
+
+	// Test nil and empty fields
+	trait.NewDerive(Team{}).DebugPretty()
+	impl, _ = trait.Lookup("Debug", Team{})
+	output = impl.(struct {
+		DebugFunc func() string
+	}).DebugFunc()
+	for _, line := range []string{`    Name: "",`, "    Members: nil,", "    Lead: nil,", "    Origin: trait_test.Point {\n        X: 0,"} {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+}
+
+func TestDeriveDebugPrettyCyclic(t *testing.T) {
+	trait.ClearRegistry()
+
+	value := *cycle(1, 2)
+	trait.NewDerive(value).DebugPretty()
+	impl, _ := trait.Lookup("Debug", value)
+	output := impl.(struct {
+		DebugFunc func() string
+	}).DebugFunc()
+
+	// Test a pointer back into the current path is printed as a cycle
+	expected := `trait_test.Node {
+    Value: 1,
+    Next: &trait_test.Node {
+        Value: 2,
+        Next: &trait_test.Node {
+            Value: 1,
+            Next: &<cycle>,
+        },
+    },
+}`
+	if output != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, output)
+	}
+}
+
+func TestDeriveClone(t *testing.T) {
+	trait.ClearRegistry()
+
+	team := Team{
+		Name:    "core",
+		Members: []string{"alice", "bob"},
+		Scores:  map[string]int{"alice": 3},
+		Lead:    &Person{Name: "alice", Age: 30},
+		Origin:  Point{X: 1, Y: 2},
+	}
+	trait.NewDerive(team).Clone()
+
+	impl, found := trait.Lookup("Clone", team)
+	if !found {
+		t.Fatal("Clone trait should be found for Team")
+	}
+	cloned := impl.(struct {
+		CloneFunc func() interface{}
+	}).CloneFunc().(Team)
+
+	if cloned.Name != "core" || len(cloned.Members) != 2 || cloned.Scores["alice"] != 3 ||
+		cloned.Lead.Name != "alice" || cloned.Origin != team.Origin {
+		t.Errorf("Clone should copy all fields, got %+v", cloned)
+	}
+
+	// Mutating the clone must not affect the original
+	cloned.Members[0] = "carol"
+	cloned.Members = append(cloned.Members, "dave")
+	cloned.Scores["alice"] = 99
+	cloned.Lead.Age = 99
+	cloned.Origin.X = 99
+
+	if team.Members[0] != "alice" || len(team.Members) != 2 {
+		t.Errorf("Original slice should be unaffected, got %v", team.Members)
+	}
+	if team.Scores["alice"] != 3 {
+		t.Errorf("Original map should be unaffected, got %v", team.Scores)
+	}
+	if team.Lead.Age != 30 {
+		t.Errorf("Original pointer target should be unaffected, got %d", team.Lead.Age)
+	}
+	if team.Origin.X != 1 {
+		t.Errorf("Original nested struct should be unaffected, got %v", team.Origin)
+	}
+
+	// Test cloning a pointer target
+	lead := &Person{Name: "erin", Age: 40}
+	trait.NewDerive(lead).Clone()
+	impl, _ = trait.Lookup("Clone", lead)
+	clonedLead := impl.(struct {
+		CloneFunc func() interface{}
+	}).CloneFunc().(*Person)
+	if clonedLead == lead || *clonedLead != *lead {
+		t.Error("Clone of a pointer should be a distinct pointer to an equal value")
+	}
+}
+
+func TestDeriveOrd(t *testing.T) {
+	trait.ClearRegistry()
+
+	trait.NewDerive(Person{}).Ord()
+	impl, found := trait.Lookup("Ord", Person{})
+	if !found {
+		t.Fatal("Ord trait should be found for Person")
+	}
+	cmpFunc := impl.(struct {
+		CmpFunc func(a, b interface{}) int
+	}).CmpFunc
+
+	// Test field-by-field comparison
+	if cmpFunc(Person{Name: "Alice", Age: 40}, Person{Name: "Bob", Age: 20}) != -1 {
+		t.Error("Expected the first field to decide the order")
+	}
+	if cmpFunc(Person{Name: "Alice", Age: 40}, Person{Name: "Alice", Age: 20}) != 1 {
+		t.Error("Expected ties on the first field to fall through to the second")
+	}
+	if cmpFunc(Person{Name: "Alice", Age: 40}, Person{Name: "Alice", Age: 40}) != 0 {
+		t.Error("Expected equal values to compare as 0")
+	}
+
+	// Test sorting with ties
+	people := []Person{
+		{Name: "Carol", Age: 30},
+		{Name: "Alice", Age: 35},
+		{Name: "Bob", Age: 25},
+		{Name: "Alice", Age: 28},
+		{Name: "Bob", Age: 25},
+	}
+	sort.SliceStable(people, func(i, j int) bool {
+		return cmpFunc(people[i], people[j]) < 0
+	})
+	expected := []Person{
+		{Name: "Alice", Age: 28},
+		{Name: "Alice", Age: 35},
+		{Name: "Bob", Age: 25},
+		{Name: "Bob", Age: 25},
+		{Name: "Carol", Age: 30},
+	}
+	for i := range expected {
+		if people[i] != expected[i] {
+			t.Errorf("Expected %v at index %d, got %v", expected[i], i, people[i])
+		}
+	}
+
+	// Test values of other types order by type name, with nil first
+	if cmpFunc(Person{}, Point{}) != -1 || cmpFunc(Point{}, Person{}) != 1 {
+		t.Error("Expected values of different types to be ordered by type name")
+	}
+	if cmpFunc(nil, Person{}) != -1 || cmpFunc(Person{}, nil) != 1 || cmpFunc(nil, nil) != 0 {
+		t.Error("Expected nil to order before any value")
+	}
+	mixed := []interface{}{Point{X: 1}, Person{Name: "Bob"}, nil, Person{Name: "Alice"}}
+	sort.Slice(mixed, func(i, j int) bool { return cmpFunc(mixed[i], mixed[j]) < 0 })
+	if mixed[0] != nil || mixed[1] != (Person{Name: "Alice"}) || mixed[2] != (Person{Name: "Bob"}) || mixed[3] != (Point{X: 1}) {
+		t.Errorf("Expected nil, Alice, Bob, then the point, got %v", mixed)
+	}
+}
+
+func TestDeriveOrdCyclic(t *testing.T) {
+	trait.ClearRegistry()
+
+	trait.NewDerive(Node{}).Ord()
+	impl, _ := trait.Lookup("Ord", Node{})
+	cmpFunc := impl.(struct {
+		CmpFunc func(a, b interface{}) int
+	}).CmpFunc
+
+	// Test cyclic values compare without recursing forever
+	if cmpFunc(*cycle(1, 2), *cycle(1, 2)) != 0 {
+		t.Error("Expected equal cyclic values to compare as 0")
+	}
+	if cmpFunc(*cycle(1, 2), *cycle(1, 3)) != -1 {
+		t.Error("Expected a difference inside the cycle to decide the order")
+	}
+	if cmpFunc(*cycle(1, 3), *cycle(1, 2)) != 1 {
+		t.Error("Expected the reversed comparison to be 1")
+	}
+}
+
+func TestDeriveHash(t *testing.T) {
+	trait.ClearRegistry()
+
+	hashOf := func(value interface{}) uint64 {
+		trait.NewDerive(value).Hash()
+		impl, found := trait.Lookup("Hash", value)
+		if !found {
+			t.Fatalf("Hash trait should be found for %T", value)
+		}
+		return impl.(struct {
+			HashFunc func() uint64
+		}).HashFunc()
+	}
+
+	// Test equal values hash identically
+	a := Team{Name: "core", Members: []string{"alice"}, Scores: map[string]int{"alice": 1, "bob": 2}}
+	b := Team{Name: "core", Members: []string{"alice"}, Scores: map[string]int{"bob": 2, "alice": 1}}
+	if hashOf(a) != hashOf(b) {
+		t.Error("Equal structs should hash identically")
+	}
+	if hashOf(Person{Name: "Alice", Age: 30}) != hashOf(Person{Name: "Alice", Age: 30}) {
+		t.Error("Hash should be stable across calls")
+	}
+
+	// Test different values usually differ
+	if hashOf(Person{Name: "Alice", Age: 30}) == hashOf(Person{Name: "Alice", Age: 31}) {
+		t.Error("Different ages should hash differently")
+	}
+	if hashOf(Point{X: 1, Y: 2}) == hashOf(Point{X: 2, Y: 1}) {
+		t.Error("Swapped fields should hash differently")
+	}
+	if hashOf(Team{Members: []string{"ab", "c"}}) == hashOf(Team{Members: []string{"a", "bc"}}) {
+		t.Error("Adjacent strings should not run together")
+	}
+}
+
+func TestDeriveHashCyclic(t *testing.T) {
+	trait.ClearRegistry()
+
+	hashOf := func(value Node) uint64 {
+		trait.NewDerive(value).Hash()
+		impl, _ := trait.Lookup("Hash", value)
+		return impl.(struct {
+			HashFunc func() uint64
+		}).HashFunc()
+	}
+
+	// Test cyclic values hash without recursing forever
+	if hashOf(*cycle(1)) != hashOf(*cycle(1)) {
+		t.Error("Equal cyclic values should hash identically")
+	}
+	if hashOf(*cycle(1, 2)) == hashOf(*cycle(1, 3)) {
+		t.Error("Different cyclic values should hash differently")
+	}
+}
+
+// Test cloning values holding a pointer to a struct and to its first field
+type Inner struct {
+	Value int
+}
+
+type Outer struct {
+	Inner Inner
+	Label string
+}
+
+type InteriorPointers struct {
+	Whole *Outer
+	Field *Inner
+}
+
+func TestDeriveCloneInteriorPointer(t *testing.T) {
+	trait.ClearRegistry()
+
+	outer := &Outer{Inner: Inner{Value: 7}, Label: "outer"}
+	value := InteriorPointers{Whole: outer, Field: &outer.Inner}
+	trait.NewDerive(value).Clone()
+
+	impl, ok := trait.Lookup("Clone", value)
+	if !ok {
+		t.Fatal("Clone should be implemented")
+	}
+	cloned := impl.(struct {
+		CloneFunc func() interface{}
+	}).CloneFunc().(InteriorPointers)
+
+	if cloned.Whole == outer || cloned.Field == &outer.Inner {
+		t.Error("Clone should not share pointers with the original")
+	}
+	if cloned.Whole.Label != "outer" || cloned.Whole.Inner.Value != 7 || cloned.Field.Value != 7 {
+		t.Errorf("Expected copied values, got %+v and %+v", *cloned.Whole, *cloned.Field)
+	}
+}
+
+func TestDeriveEq(t *testing.T) {
+	trait.ClearRegistry()
+
+	trait.NewDerive(Point{X: 1, Y: 2}).Eq()
+	impl, found := trait.Lookup("Eq", Point{})
+	if !found {
+		t.Fatal("Eq trait should be found for Point")
+	}
+	eqFunc := impl.(struct {
+		EqFunc func(a, b interface{}) bool
+	}).EqFunc
+
+	// Test that both arguments are compared, not the derived target
+	if !eqFunc(Point{X: 5, Y: 6}, Point{X: 5, Y: 6}) {
+		t.Error("Expected equal points to be equal")
+	}
+	if eqFunc(Point{X: 1, Y: 2}, Point{X: 2, Y: 1}) {
+		t.Error("Expected different points to be unequal")
+	}
+	if eqFunc(Point{X: 7, Y: 8}, Point{X: 1, Y: 2}) {
+		t.Error("Expected a value equal to the target not to affect the result")
+	}
+	if eqFunc(Point{X: 1, Y: 2}, Person{}) {
+		t.Error("Expected values of different types to be unequal")
+	}
+}
+
+type Account struct {
+	ID       string   `json:"id"`
+	Owner    string   `json:"owner"`
+	Balance  float64  `json:"balance"`
+	Tags     []string `json:"tags,omitempty"`
+	Password string   `json:"-"`
+	Region   string
+	internal int
+}
+
+func TestDeriveSerialize(t *testing.T) {
+	trait.ClearRegistry()
+
+	account := Account{ID: "A1", Owner: "Grace", Balance: 12.5, Password: "secret", Region: "eu", internal: 7}
+	trait.NewDerive(account).Serialize()
+
+	impl, found := trait.Lookup("Serialize", account)
+	if !found {
+		t.Fatal("Serialize trait should be found for Account")
+	}
+	serialize := impl.(struct {
+		ToJSONFunc func() ([]byte, error)
+		ToMapFunc  func() map[string]interface{}
+	})
+
+	// Test JSON round trip
+	data, err := serialize.ToJSONFunc()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	var decoded Account
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.ID != "A1" || decoded.Owner != "Grace" || decoded.Balance != 12.5 || decoded.Region != "eu" {
+		t.Errorf("Expected JSON round trip to preserve fields, got %+v", decoded)
+	}
+	if decoded.Password != "" {
+		t.Error("Expected fields tagged '-' to be omitted from JSON")
+	}
+
+	// Test map conversion
+	m := serialize.ToMapFunc()
+	if m["id"] != "A1" || m["owner"] != "Grace" || m["balance"] != 12.5 || m["Region"] != "eu" {
+		t.Errorf("Expected map keys to follow json tags, got %v", m)
+	}
+	for _, key := range []string{"Password", "tags", "internal", "ID"} {
+		if _, ok := m[key]; ok {
+			t.Errorf("Expected key %q to be absent, got %v", key, m)
+		}
+	}
+
+	// Test map round trip through JSON
+	fromMap, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal of map failed: %v", err)
+	}
+	var again Account
+	if err := json.Unmarshal(fromMap, &again); err != nil {
+		t.Fatalf("Unmarshal of map JSON failed: %v", err)
+	}
+	if again.ID != "A1" || again.Owner != "Grace" || again.Balance != 12.5 || again.Region != "eu" {
+		t.Errorf("Expected map round trip to match, got %+v", again)
+	}
+}
+
+type ServerConfig struct {
+	Host     string        `default:"localhost"`
+	Port     int           `default:"8080"`
+	Ratio    float64       `default:"0.75"`
+	Debug    bool          `default:"true"`
+	Timeout  time.Duration `default:"1500ms"`
+	Retries  uint8         `default:"3"`
+	Name     string
+	Limits   Limits
+	internal int
+}
+
+type Limits struct {
+	MaxConns int `default:"100"`
+	Burst    int
+}
+
+func TestDeriveDefault(t *testing.T) {
+	trait.ClearRegistry()
+
+	trait.NewDerive(ServerConfig{}).Default()
+	impl, found := trait.Lookup("Default", ServerConfig{})
+	if !found {
+		t.Fatal("Default trait should be found for ServerConfig")
+	}
+	defaultFunc := impl.(struct {
+		DefaultFunc func() interface{}
+	}).DefaultFunc
+
+	// Test tagged and untagged fields
+	cfg := defaultFunc().(ServerConfig)
+	if cfg.Host != "localhost" || cfg.Port != 8080 || cfg.Ratio != 0.75 || !cfg.Debug ||
+		cfg.Timeout != 1500*time.Millisecond || cfg.Retries != 3 {
+		t.Errorf("Expected tagged fields to be populated, got %+v", cfg)
+	}
+	if cfg.Name != "" || cfg.internal != 0 {
+		t.Errorf("Expected untagged fields to stay zero, got %+v", cfg)
+	}
+
+	// Test nested struct defaults
+	if cfg.Limits.MaxConns != 100 || cfg.Limits.Burst != 0 {
+		t.Errorf("Expected nested defaults to be applied, got %+v", cfg.Limits)
+	}
+
+	// Test pointer targets
+	trait.NewDerive(&Limits{}).Default()
+	impl, _ = trait.Lookup("Default", &Limits{})
+	limits := impl.(struct {
+		DefaultFunc func() interface{}
+	}).DefaultFunc().(*Limits)
+	if limits == nil || limits.MaxConns != 100 {
+		t.Errorf("Expected a pointer with defaults applied, got %v", limits)
+	}
+
+	// Test invalid tag
+	type BadConfig struct {
+		Port int `default:"eighty"`
+	}
+	if err := trait.NewDerive(BadConfig{}).Default().Err(); err == nil {
+		t.Error("Default should report an unparsable tag")
+	}
+	if trait.HasTrait("Default", BadConfig{}) {
+		t.Error("Default should not be registered when a tag is invalid")
+	}
+}
+
+func TestDeriveFromStr(t *testing.T) {
+	trait.ClearRegistry()
+
+	parser := func(target interface{}) func(string) (interface{}, error) {
+		trait.NewDerive(target).FromStr()
+		impl, found := trait.Lookup("FromStr", target)
+		if !found {
+			t.Fatalf("FromStr trait should be found for %T", target)
+		}
+		return impl.(struct {
+			FromStrFunc func(s string) (interface{}, error)
+		}).FromStrFunc
+	}
+
+	// Test numeric targets
+	parseInt := parser(0)
+	value, err := parseInt("42")
+	if err != nil || value.(int) != 42 {
+		t.Errorf("Expected 42, got %v, %v", value, err)
+	}
+	if _, err := parseInt("forty-two"); err == nil {
+		t.Error("Expected an error for a non-numeric string")
+	}
+	value, err = parser(float64(0))("2.5")
+	if err != nil || value.(float64) != 2.5 {
+		t.Errorf("Expected 2.5, got %v, %v", value, err)
+	}
+	if _, err := parser(int8(0))("300"); err == nil {
+		t.Error("Expected an error for an out-of-range int8")
+	}
+
+	// Test struct targets
+	parsePerson := parser(Person{})
+	value, err = parsePerson(`{"Name": "Ivan", "Age": 41}`)
+	if err != nil || value.(Person) != (Person{Name: "Ivan", Age: 41}) {
+		t.Errorf("Expected Person{Ivan 41}, got %v, %v", value, err)
+	}
+	if _, err := parsePerson(`{"Name": `); err == nil {
+		t.Error("Expected an error for malformed JSON")
+	}
+	if _, err := parsePerson(`{"Age": "old"}`); err == nil {
+		t.Error("Expected an error for a mistyped JSON field")
+	}
+
+	// Test pointer targets
+	value, err = parser(&Point{})(`{"X": 3, "Y": 4}`)
+	if err != nil || *value.(*Point) != (Point{X: 3, Y: 4}) {
+		t.Errorf("Expected &Point{3 4}, got %v, %v", value, err)
+	}
+}
+
+func TestDeriveErrors(t *testing.T) {
+	trait.ClearRegistry()
+
+	// Test supported derivations report no error
+	if err := trait.NewDerive(Person{}).Display().Debug().Clone().Eq().Ord().Hash().Serialize().Default().FromStr().Err(); err != nil {
+		t.Errorf("Expected no error for Person, got %v", err)
+	}
+
+	// Test unsupported kinds are reported and not registered
+	callback := func() {}
+	d := trait.NewDerive(callback).Display().Hash().Serialize().FromStr().Clone()
+	err := d.Err()
+	if err == nil {
+		t.Fatal("Expected errors deriving traits for a func")
+	}
+	for _, name := range []string{"Display", "Hash", "Serialize", "FromStr"} {
+		if !strings.Contains(err.Error(), "cannot derive "+name) {
+			t.Errorf("Expected an error for %s, got %v", name, err)
+		}
+		if trait.HasTrait(name, callback) {
+			t.Errorf("%s should not be registered for a func", name)
+		}
+	}
+	if !trait.HasTrait("Clone", callback) {
+		t.Error("Supported derivations should still be registered")
+	}
+
+	// Test nested fields without a natural order
+	type Tagged struct {
+		Name string
+		Tags map[string]bool
+	}
+	err = trait.NewDerive(Tagged{}).Ord().Err()
+	if err == nil || !strings.Contains(err.Error(), "field Tags") {
+		t.Errorf("Expected an Ord error naming the map field, got %v", err)
+	}
+
+	// Test a nil target
+	if err := trait.NewDerive(nil).Eq().Err(); err == nil {
+		t.Error("Expected an error deriving for a nil target")
+	}
+
+	// Test rejected duplicates are reported instead of panicking
+	previous := trait.SetDuplicatePolicy(trait.RejectDuplicates)
+	defer trait.SetDuplicatePolicy(previous)
+	if err := trait.NewDerive(Person{}).Display().Err(); err == nil {
+		t.Error("Expected an error for a rejected duplicate derivation")
+	}
+}
+
 func TestTraitComposition(t *testing.T) {
 	trait.ClearRegistry()
 
@@ -201,6 +1004,50 @@ func TestTraitComposition(t *testing.T) {
 	}
 }
 
+func TestTraitCompositionRequires(t *testing.T) {
+	trait.ClearRegistry()
+
+	person := Person{Name: "Heidi", Age: 33}
+	trait.NewDerive(person).Display().Ord()
+
+	// Test missing required traits
+	comp := trait.Compose("Ord").Requires("Eq", "Display", "Hash")
+	if _, err := comp.TryImplement(person); err == nil {
+		t.Error("TryImplement should fail when required traits are missing")
+	} else if err.Error() != "value of type trait_test.Person is missing required traits: Eq, Hash" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	// Test Implement records the missing required traits
+	partial := comp.Implement(person)
+	if missing := partial.Missing(); len(missing) != 2 || missing[0] != "Eq" || missing[1] != "Hash" {
+		t.Errorf("Expected missing [Eq Hash], got %v", missing)
+	}
+	if _, found := partial.GetTrait("Display"); !found {
+		t.Error("Implement should include the required traits that are present")
+	}
+
+	// Test all required traits present
+	trait.NewDerive(person).Eq().Hash()
+	impl, err := comp.TryImplement(person)
+	if err != nil {
+		t.Fatalf("TryImplement should succeed once all traits are present: %v", err)
+	}
+	for _, name := range []string{"Ord", "Eq", "Display", "Hash"} {
+		if _, found := impl.GetTrait(name); !found {
+			t.Errorf("%s trait should be present", name)
+		}
+	}
+	if missing := comp.Implement(person).Missing(); len(missing) != 0 {
+		t.Errorf("Expected no missing traits, got %v", missing)
+	}
+
+	// Test composition without requirements
+	if _, err := trait.Compose("Display").TryImplement(person); err != nil {
+		t.Errorf("Composition without requirements should not fail: %v", err)
+	}
+}
+
 func TestTraitBound(t *testing.T) {
 	trait.ClearRegistry()
 
@@ -344,6 +1191,152 @@ func TestGetTraitNames(t *testing.T) {
 	}
 }
 
+func TestConcurrentRegistration(t *testing.T) {
+	trait.ClearRegistry()
+
+	type Sample struct{ N int }
+	values := []interface{}{Person{}, Point{}, Sample{}, 0, "", 1.5, true}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			value := values[i%len(values)]
+			trait.NewDerive(value).Display().Debug().Clone().Eq().Ord().Hash().Default()
+			trait.Register(trait.DisplayTrait, struct{ ID int }{ID: i})
+			trait.TraitAlias("Display", "Show")
+			_ = trait.HasTrait("Display", value)
+			_ = trait.NewBound("Eq").Check(value)
+			_, _ = trait.Lookup("Clone", value)
+			_ = trait.GetTraitNames()
+			_ = trait.Compose("Display", "Debug").Implement(value)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, value := range values {
+		if !trait.HasTrait("Display", value) || !trait.HasTrait("Hash", value) {
+			t.Errorf("Expected %T to have Display and Hash after concurrent registration", value)
+		}
+	}
+}
+
+func TestRemoveTrait(t *testing.T) {
+	trait.ClearRegistry()
+
+	point := Point{X: 1, Y: 2}
+	trait.NewDerive(point).Display().Debug()
+
+	// Test removing a registered implementation
+	if !trait.HasTrait("Display", point) {
+		t.Fatal("Point should have Display trait")
+	}
+	if !trait.RemoveTrait("Display", point) {
+		t.Error("RemoveTrait should report a removal")
+	}
+	if trait.HasTrait("Display", point) {
+		t.Error("Point should not have Display trait after removal")
+	}
+	if !trait.HasTrait("Debug", point) {
+		t.Error("Removing Display should not affect Debug")
+	}
+
+	// Test removing something that is not registered
+	if trait.RemoveTrait("Display", point) {
+		t.Error("RemoveTrait should report false for a missing implementation")
+	}
+	if trait.RemoveTrait("NonExistent", point) {
+		t.Error("RemoveTrait should report false for an unknown trait")
+	}
+
+	// Test re-registering after removal
+	trait.NewDerive(point).Display()
+	if !trait.HasTrait("Display", point) {
+		t.Error("Point should have Display trait after re-registration")
+	}
+}
+
+func TestRemoveTraitThroughAlias(t *testing.T) {
+	trait.ClearRegistry()
+
+	point := Point{X: 1, Y: 2}
+	trait.NewDerive(point).Display()
+	trait.TraitAlias("Display", "Show")
+
+	// Test removing through the alias leaves the original untouched
+	if !trait.RemoveTrait("Show", point) {
+		t.Fatal("RemoveTrait should remove the aliased implementation")
+	}
+	if trait.HasTrait("Show", point) {
+		t.Error("Alias should no longer have the implementation")
+	}
+	if !trait.HasTrait("Display", point) {
+		t.Error("Removing through an alias should not affect the original trait")
+	}
+
+	// Test registering under the alias does not write into the original
+	trait.NewDerive(Person{}).Display()
+	trait.TraitAlias("Display", "Show")
+	trait.RemoveTrait("Display", Person{})
+	if !trait.HasTrait("Show", Person{}) {
+		t.Error("Removing from the original should not affect the alias")
+	}
+	trait.NewDerive(42).Display()
+	trait.RemoveTrait("Display", 42)
+	trait.TraitAlias("Display", "Show")
+	trait.NewDerive("text").Display()
+	if trait.HasTrait("Show", "text") {
+		t.Error("Later registrations on the original should not appear under the alias")
+	}
+}
+
+func TestDuplicatePolicy(t *testing.T) {
+	trait.ClearRegistry()
+	previous := trait.SetDuplicatePolicy(trait.RejectDuplicates)
+	defer trait.SetDuplicatePolicy(previous)
+
+	type Impl struct{ Name string }
+
+	// Test the first registration succeeds
+	if err := trait.TryRegister(trait.DisplayTrait, Impl{Name: "first"}); err != nil {
+		t.Fatalf("First registration should succeed: %v", err)
+	}
+
+	// Test duplicates are rejected and the original is kept
+	if err := trait.TryRegister(trait.DisplayTrait, Impl{Name: "second"}); err == nil {
+		t.Error("Duplicate registration should be rejected")
+	}
+	impl, _ := trait.Get[trait.Display, Impl](trait.DisplayTrait)
+	if impl.Name != "first" {
+		t.Errorf("Expected the original implementation to be kept, got %q", impl.Name)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Register should panic for a rejected duplicate")
+			}
+		}()
+		trait.Register(trait.DisplayTrait, Impl{Name: "third"})
+	}()
+
+	// Test swapping an implementation by removing it first
+	trait.RemoveTrait("Display", Impl{})
+	if err := trait.TryRegister(trait.DisplayTrait, Impl{Name: "swapped"}); err != nil {
+		t.Errorf("Registration after removal should succeed: %v", err)
+	}
+
+	// Test overwriting when duplicates are allowed
+	trait.SetDuplicatePolicy(trait.OverwriteDuplicates)
+	if err := trait.TryRegister(trait.DisplayTrait, Impl{Name: "overwritten"}); err != nil {
+		t.Errorf("Overwrite should succeed: %v", err)
+	}
+	impl, _ = trait.Get[trait.Display, Impl](trait.DisplayTrait)
+	if impl.Name != "overwritten" {
+		t.Errorf("Expected 'overwritten', got %q", impl.Name)
+	}
+}
+
 func TestHasTrait(t *testing.T) {
 	trait.ClearRegistry()
 
@@ -361,6 +1354,35 @@ func TestHasTrait(t *testing.T) {
 	}
 }
 
+func TestHasTraits(t *testing.T) {
+	trait.ClearRegistry()
+
+	person := Person{Name: "Judy", Age: 22}
+	trait.NewDerive(person).Display().Eq()
+
+	// Test a value with some but not all traits
+	if trait.HasTraits(person, "Display", "Eq", "Hash") {
+		t.Error("HasTraits should be false when Hash is missing")
+	}
+	missing := trait.MissingTraits(person, "Hash", "Display", "Ord", "Eq")
+	if len(missing) != 2 || missing[0] != "Hash" || missing[1] != "Ord" {
+		t.Errorf("Expected [Hash Ord], got %v", missing)
+	}
+
+	// Test a value with all traits
+	if !trait.HasTraits(person, "Display", "Eq") {
+		t.Error("HasTraits should be true when all traits are present")
+	}
+	if missing := trait.MissingTraits(person, "Display", "Eq"); len(missing) != 0 {
+		t.Errorf("Expected no missing traits, got %v", missing)
+	}
+
+	// Test no traits requested
+	if !trait.HasTraits(person) {
+		t.Error("HasTraits with no names should be true")
+	}
+}
+
 func TestSingletonTraits(t *testing.T) {
 	// Test that singleton traits are properly defined
 	// Note: traitName() is a private method, so we can't test it directly
@@ -451,3 +1473,21 @@ func TestMultipleTypeImplementations(t *testing.T) {
 		t.Error("float64 should have Display trait")
 	}
 }
+
+// BenchmarkTraitObjectCall measures repeated dispatch of one method.
+// Most of the time is spent in reflect.Value.Call itself; resolving methods
+// once per trait object and method only removes the per-call vtable reflection.
+func BenchmarkTraitObjectCall(b *testing.B) {
+	person := Person{Name: "Kim", Age: 45}
+	obj := trait.NewTraitObject(person, map[string]interface{}{
+		"OlderBy": func(p Person, years int) int { return p.Age + years },
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := obj.Call("OlderBy", 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}